@@ -0,0 +1,3632 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: agent/agent.proto
+
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on RunRequest with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RunRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RunRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RunRequestMultiError, or
+// nil if none found.
+func (m *RunRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RunRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetComputation()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RunRequestValidationError{
+					field:  "Computation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RunRequestValidationError{
+					field:  "Computation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetComputation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RunRequestValidationError{
+				field:  "Computation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RunRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RunRequestMultiError is an error wrapping multiple validation errors
+// returned by RunRequest.ValidateAll() if the designated constraints aren't met.
+type RunRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RunRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RunRequestMultiError) AllErrors() []error { return m }
+
+// RunRequestValidationError is the validation error returned by
+// RunRequest.Validate if the designated constraints aren't met.
+type RunRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RunRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RunRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RunRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RunRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RunRequestValidationError) ErrorName() string { return "RunRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RunRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRunRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RunRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RunRequestValidationError{}
+
+// Validate checks the field values on RunResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RunResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RunResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RunResponseMultiError, or
+// nil if none found.
+func (m *RunResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RunResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetComputation()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RunResponseValidationError{
+					field:  "Computation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RunResponseValidationError{
+					field:  "Computation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetComputation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RunResponseValidationError{
+				field:  "Computation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RunResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RunResponseMultiError is an error wrapping multiple validation errors
+// returned by RunResponse.ValidateAll() if the designated constraints aren't met.
+type RunResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RunResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RunResponseMultiError) AllErrors() []error { return m }
+
+// RunResponseValidationError is the validation error returned by
+// RunResponse.Validate if the designated constraints aren't met.
+type RunResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RunResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RunResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RunResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RunResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RunResponseValidationError) ErrorName() string { return "RunResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RunResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRunResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RunResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RunResponseValidationError{}
+
+// Validate checks the field values on ComputationManifest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ComputationManifest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ComputationManifest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ComputationManifestMultiError, or nil if none found.
+func (m *ComputationManifest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ComputationManifest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for Description
+
+	// no validation rules for Status
+
+	// no validation rules for Owner
+
+	if all {
+		switch v := interface{}(m.GetStartTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationManifestValidationError{
+				field:  "StartTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationManifestValidationError{
+				field:  "EndTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetAlgorithms()) < 1 {
+		err := ComputationManifestValidationError{
+			field:  "Algorithms",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_ComputationManifest_Algorithms_Unique := make(map[string]struct{}, len(m.GetAlgorithms()))
+
+	for idx, item := range m.GetAlgorithms() {
+		_, _ = idx, item
+
+		if _, exists := _ComputationManifest_Algorithms_Unique[item]; exists {
+			err := ComputationManifestValidationError{
+				field:  fmt.Sprintf("Algorithms[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_ComputationManifest_Algorithms_Unique[item] = struct{}{}
+		}
+
+		if !_ComputationManifest_Algorithms_Pattern.MatchString(item) {
+			err := ComputationManifestValidationError{
+				field:  fmt.Sprintf("Algorithms[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9a-fA-F]{64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetDatasets()) < 1 {
+		err := ComputationManifestValidationError{
+			field:  "Datasets",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_ComputationManifest_Datasets_Unique := make(map[string]struct{}, len(m.GetDatasets()))
+
+	for idx, item := range m.GetDatasets() {
+		_, _ = idx, item
+
+		if _, exists := _ComputationManifest_Datasets_Unique[item]; exists {
+			err := ComputationManifestValidationError{
+				field:  fmt.Sprintf("Datasets[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_ComputationManifest_Datasets_Unique[item] = struct{}{}
+		}
+
+		if !_ComputationManifest_Datasets_Pattern.MatchString(item) {
+			err := ComputationManifestValidationError{
+				field:  fmt.Sprintf("Datasets[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9a-fA-F]{64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetAlgorithmProviders()) < 1 {
+		err := ComputationManifestValidationError{
+			field:  "AlgorithmProviders",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetAlgorithmProviders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("AlgorithmProviders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("AlgorithmProviders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ComputationManifestValidationError{
+					field:  fmt.Sprintf("AlgorithmProviders[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(m.GetDatasetProviders()) < 1 {
+		err := ComputationManifestValidationError{
+			field:  "DatasetProviders",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetDatasetProviders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("DatasetProviders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("DatasetProviders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ComputationManifestValidationError{
+					field:  fmt.Sprintf("DatasetProviders[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(m.GetResultConsumers()) < 1 {
+		err := ComputationManifestValidationError{
+			field:  "ResultConsumers",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetResultConsumers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("ResultConsumers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("ResultConsumers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ComputationManifestValidationError{
+					field:  fmt.Sprintf("ResultConsumers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetLogReaders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("LogReaders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ComputationManifestValidationError{
+						field:  fmt.Sprintf("LogReaders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ComputationManifestValidationError{
+					field:  fmt.Sprintf("LogReaders[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Runtime
+
+	if all {
+		switch v := interface{}(m.GetSandbox()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Sandbox",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Sandbox",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSandbox()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationManifestValidationError{
+				field:  "Sandbox",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLimits()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Limits",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Limits",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLimits()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationManifestValidationError{
+				field:  "Limits",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetTtl() < 0 {
+		err := ComputationManifestValidationError{
+			field:  "Ttl",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationManifestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationManifestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ComputationManifestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ComputationManifestMultiError is an error wrapping multiple validation
+// errors returned by ComputationManifest.ValidateAll() if the designated
+// constraints aren't met.
+type ComputationManifestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ComputationManifestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ComputationManifestMultiError) AllErrors() []error { return m }
+
+// ComputationManifestValidationError is the validation error returned by
+// ComputationManifest.Validate if the designated constraints aren't met.
+type ComputationManifestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ComputationManifestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ComputationManifestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ComputationManifestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ComputationManifestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ComputationManifestValidationError) ErrorName() string {
+	return "ComputationManifestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ComputationManifestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sComputationManifest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ComputationManifestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ComputationManifestValidationError{}
+
+var _ComputationManifest_Algorithms_Pattern = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+var _ComputationManifest_Datasets_Pattern = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+// Validate checks the field values on Party with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Party) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Party with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PartyMultiError, or nil if none found.
+func (m *Party) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Party) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := PartyValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PartyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PartyMultiError is an error wrapping multiple validation errors returned by
+// Party.ValidateAll() if the designated constraints aren't met.
+type PartyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PartyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PartyMultiError) AllErrors() []error { return m }
+
+// PartyValidationError is the validation error returned by Party.Validate if
+// the designated constraints aren't met.
+type PartyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PartyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PartyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PartyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PartyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PartyValidationError) ErrorName() string { return "PartyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PartyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sParty.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PartyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PartyValidationError{}
+
+// Validate checks the field values on ResultConsumer with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ResultConsumer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResultConsumer with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResultConsumerMultiError,
+// or nil if none found.
+func (m *ResultConsumer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResultConsumer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := ResultConsumerValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_ResultConsumer_PublicKey_Pattern.MatchString(m.GetPublicKey()) {
+		err := ResultConsumerValidationError{
+			field:  "PublicKey",
+			reason: "value does not match regex pattern \"^([0-9a-fA-F]{64})?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResultConsumerMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResultConsumerMultiError is an error wrapping multiple validation errors
+// returned by ResultConsumer.ValidateAll() if the designated constraints
+// aren't met.
+type ResultConsumerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResultConsumerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResultConsumerMultiError) AllErrors() []error { return m }
+
+// ResultConsumerValidationError is the validation error returned by
+// ResultConsumer.Validate if the designated constraints aren't met.
+type ResultConsumerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResultConsumerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResultConsumerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResultConsumerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResultConsumerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResultConsumerValidationError) ErrorName() string { return "ResultConsumerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResultConsumerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResultConsumer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResultConsumerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResultConsumerValidationError{}
+
+var _ResultConsumer_PublicKey_Pattern = regexp.MustCompile("^([0-9a-fA-F]{64})?$")
+
+// Validate checks the field values on SandboxOptions with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SandboxOptions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SandboxOptions with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SandboxOptionsMultiError,
+// or nil if none found.
+func (m *SandboxOptions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SandboxOptions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.UserNamespace != nil {
+		// no validation rules for UserNamespace
+	}
+
+	if m.MountNamespace != nil {
+		// no validation rules for MountNamespace
+	}
+
+	if m.PidNamespace != nil {
+		// no validation rules for PidNamespace
+	}
+
+	if m.ReadOnlyRoot != nil {
+		// no validation rules for ReadOnlyRoot
+	}
+
+	if m.Seccomp != nil {
+		// no validation rules for Seccomp
+	}
+
+	if m.DropCapabilities != nil {
+		// no validation rules for DropCapabilities
+	}
+
+	if m.Network != nil {
+		// no validation rules for Network
+	}
+
+	if len(errors) > 0 {
+		return SandboxOptionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// SandboxOptionsMultiError is an error wrapping multiple validation errors
+// returned by SandboxOptions.ValidateAll() if the designated constraints
+// aren't met.
+type SandboxOptionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SandboxOptionsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SandboxOptionsMultiError) AllErrors() []error { return m }
+
+// SandboxOptionsValidationError is the validation error returned by
+// SandboxOptions.Validate if the designated constraints aren't met.
+type SandboxOptionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SandboxOptionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SandboxOptionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SandboxOptionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SandboxOptionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SandboxOptionsValidationError) ErrorName() string { return "SandboxOptionsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SandboxOptionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSandboxOptions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SandboxOptionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SandboxOptionsValidationError{}
+
+// Validate checks the field values on ResourceLimits with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ResourceLimits) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResourceLimits with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResourceLimitsMultiError,
+// or nil if none found.
+func (m *ResourceLimits) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResourceLimits) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMemory() < 0 {
+		err := ResourceLimitsValidationError{
+			field:  "Memory",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetCpus() < 0 {
+		err := ResourceLimitsValidationError{
+			field:  "Cpus",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPids() < 0 {
+		err := ResourceLimitsValidationError{
+			field:  "Pids",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResourceLimitsMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResourceLimitsMultiError is an error wrapping multiple validation errors
+// returned by ResourceLimits.ValidateAll() if the designated constraints
+// aren't met.
+type ResourceLimitsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResourceLimitsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResourceLimitsMultiError) AllErrors() []error { return m }
+
+// ResourceLimitsValidationError is the validation error returned by
+// ResourceLimits.Validate if the designated constraints aren't met.
+type ResourceLimitsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResourceLimitsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResourceLimitsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResourceLimitsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResourceLimitsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResourceLimitsValidationError) ErrorName() string { return "ResourceLimitsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResourceLimitsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResourceLimits.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResourceLimitsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResourceLimitsValidationError{}
+
+// Validate checks the field values on AlgoRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AlgoRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AlgoRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AlgoRequestMultiError, or
+// nil if none found.
+func (m *AlgoRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AlgoRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Algorithm
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return AlgoRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AlgoRequestMultiError is an error wrapping multiple validation errors
+// returned by AlgoRequest.ValidateAll() if the designated constraints aren't met.
+type AlgoRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AlgoRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AlgoRequestMultiError) AllErrors() []error { return m }
+
+// AlgoRequestValidationError is the validation error returned by
+// AlgoRequest.Validate if the designated constraints aren't met.
+type AlgoRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AlgoRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AlgoRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AlgoRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AlgoRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AlgoRequestValidationError) ErrorName() string { return "AlgoRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AlgoRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAlgoRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AlgoRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AlgoRequestValidationError{}
+
+// Validate checks the field values on AlgoResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AlgoResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AlgoResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AlgoResponseMultiError, or
+// nil if none found.
+func (m *AlgoResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AlgoResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AlgorithmID
+
+	if len(errors) > 0 {
+		return AlgoResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// AlgoResponseMultiError is an error wrapping multiple validation errors
+// returned by AlgoResponse.ValidateAll() if the designated constraints aren't met.
+type AlgoResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AlgoResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AlgoResponseMultiError) AllErrors() []error { return m }
+
+// AlgoResponseValidationError is the validation error returned by
+// AlgoResponse.Validate if the designated constraints aren't met.
+type AlgoResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AlgoResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AlgoResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AlgoResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AlgoResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AlgoResponseValidationError) ErrorName() string { return "AlgoResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AlgoResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAlgoResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AlgoResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AlgoResponseValidationError{}
+
+// Validate checks the field values on DataRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DataRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DataRequestMultiError, or
+// nil if none found.
+func (m *DataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataset
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return DataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataRequestMultiError is an error wrapping multiple validation errors
+// returned by DataRequest.ValidateAll() if the designated constraints aren't met.
+type DataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataRequestMultiError) AllErrors() []error { return m }
+
+// DataRequestValidationError is the validation error returned by
+// DataRequest.Validate if the designated constraints aren't met.
+type DataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataRequestValidationError) ErrorName() string { return "DataRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DataRequestValidationError{}
+
+// Validate checks the field values on DataResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DataResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DataResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DataResponseMultiError, or
+// nil if none found.
+func (m *DataResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DataResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DatasetID
+
+	if len(errors) > 0 {
+		return DataResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataResponseMultiError is an error wrapping multiple validation errors
+// returned by DataResponse.ValidateAll() if the designated constraints aren't met.
+type DataResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataResponseMultiError) AllErrors() []error { return m }
+
+// DataResponseValidationError is the validation error returned by
+// DataResponse.Validate if the designated constraints aren't met.
+type DataResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataResponseValidationError) ErrorName() string { return "DataResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDataResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DataResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DataResponseValidationError{}
+
+// Validate checks the field values on UploadRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UploadRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UploadRequestMultiError, or
+// nil if none found.
+func (m *UploadRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Size
+
+	// no validation rules for Offset
+
+	// no validation rules for Chunk
+
+	if len(errors) > 0 {
+		return UploadRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadRequestMultiError is an error wrapping multiple validation errors
+// returned by UploadRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UploadRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadRequestMultiError) AllErrors() []error { return m }
+
+// UploadRequestValidationError is the validation error returned by
+// UploadRequest.Validate if the designated constraints aren't met.
+type UploadRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadRequestValidationError) ErrorName() string { return "UploadRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UploadRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadRequestValidationError{}
+
+// Validate checks the field values on UploadResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UploadResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UploadResponseMultiError,
+// or nil if none found.
+func (m *UploadResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Size
+
+	// no validation rules for Received
+
+	// no validation rules for Complete
+
+	if len(errors) > 0 {
+		return UploadResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadResponseMultiError is an error wrapping multiple validation errors
+// returned by UploadResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UploadResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadResponseMultiError) AllErrors() []error { return m }
+
+// UploadResponseValidationError is the validation error returned by
+// UploadResponse.Validate if the designated constraints aren't met.
+type UploadResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadResponseValidationError) ErrorName() string { return "UploadResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UploadResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadResponseValidationError{}
+
+// Validate checks the field values on UploadProgressRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UploadProgressRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadProgressRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UploadProgressRequestMultiError, or nil if none found.
+func (m *UploadProgressRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadProgressRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return UploadProgressRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadProgressRequestMultiError is an error wrapping multiple validation
+// errors returned by UploadProgressRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UploadProgressRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadProgressRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadProgressRequestMultiError) AllErrors() []error { return m }
+
+// UploadProgressRequestValidationError is the validation error returned by
+// UploadProgressRequest.Validate if the designated constraints aren't met.
+type UploadProgressRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadProgressRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadProgressRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadProgressRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadProgressRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadProgressRequestValidationError) ErrorName() string {
+	return "UploadProgressRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UploadProgressRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadProgressRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadProgressRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadProgressRequestValidationError{}
+
+// Validate checks the field values on ProvideKeyRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ProvideKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProvideKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ProvideKeyRequestMultiError, or nil if none found.
+func (m *ProvideKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProvideKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Key
+
+	if len(errors) > 0 {
+		return ProvideKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProvideKeyRequestMultiError is an error wrapping multiple validation errors
+// returned by ProvideKeyRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ProvideKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProvideKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProvideKeyRequestMultiError) AllErrors() []error { return m }
+
+// ProvideKeyRequestValidationError is the validation error returned by
+// ProvideKeyRequest.Validate if the designated constraints aren't met.
+type ProvideKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProvideKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProvideKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProvideKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProvideKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProvideKeyRequestValidationError) ErrorName() string {
+	return "ProvideKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ProvideKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProvideKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProvideKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProvideKeyRequestValidationError{}
+
+// Validate checks the field values on ProvideKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ProvideKeyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProvideKeyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ProvideKeyResponseMultiError, or nil if none found.
+func (m *ProvideKeyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProvideKeyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ProvideKeyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProvideKeyResponseMultiError is an error wrapping multiple validation errors
+// returned by ProvideKeyResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ProvideKeyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProvideKeyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProvideKeyResponseMultiError) AllErrors() []error { return m }
+
+// ProvideKeyResponseValidationError is the validation error returned by
+// ProvideKeyResponse.Validate if the designated constraints aren't met.
+type ProvideKeyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProvideKeyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProvideKeyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProvideKeyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProvideKeyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProvideKeyResponseValidationError) ErrorName() string {
+	return "ProvideKeyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ProvideKeyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProvideKeyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProvideKeyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProvideKeyResponseValidationError{}
+
+// Validate checks the field values on ResultRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ResultRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResultRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResultRequestMultiError, or
+// nil if none found.
+func (m *ResultRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResultRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ResultRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResultRequestMultiError is an error wrapping multiple validation errors
+// returned by ResultRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ResultRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResultRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResultRequestMultiError) AllErrors() []error { return m }
+
+// ResultRequestValidationError is the validation error returned by
+// ResultRequest.Validate if the designated constraints aren't met.
+type ResultRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResultRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResultRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResultRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResultRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResultRequestValidationError) ErrorName() string { return "ResultRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResultRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResultRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResultRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResultRequestValidationError{}
+
+// Validate checks the field values on ResultResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ResultResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResultResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ResultResponseMultiError,
+// or nil if none found.
+func (m *ResultResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResultResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for File
+
+	if len(errors) > 0 {
+		return ResultResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResultResponseMultiError is an error wrapping multiple validation errors
+// returned by ResultResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ResultResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResultResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResultResponseMultiError) AllErrors() []error { return m }
+
+// ResultResponseValidationError is the validation error returned by
+// ResultResponse.Validate if the designated constraints aren't met.
+type ResultResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResultResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResultResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResultResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResultResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResultResponseValidationError) ErrorName() string { return "ResultResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ResultResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResultResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResultResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResultResponseValidationError{}
+
+// Validate checks the field values on ProvenanceRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ProvenanceRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProvenanceRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ProvenanceRequestMultiError, or nil if none found.
+func (m *ProvenanceRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProvenanceRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ProvenanceRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProvenanceRequestMultiError is an error wrapping multiple validation errors
+// returned by ProvenanceRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ProvenanceRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProvenanceRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProvenanceRequestMultiError) AllErrors() []error { return m }
+
+// ProvenanceRequestValidationError is the validation error returned by
+// ProvenanceRequest.Validate if the designated constraints aren't met.
+type ProvenanceRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProvenanceRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProvenanceRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProvenanceRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProvenanceRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProvenanceRequestValidationError) ErrorName() string {
+	return "ProvenanceRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ProvenanceRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProvenanceRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProvenanceRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProvenanceRequestValidationError{}
+
+// Validate checks the field values on ProvenanceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ProvenanceResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProvenanceResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ProvenanceResponseMultiError, or nil if none found.
+func (m *ProvenanceResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProvenanceResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for File
+
+	if len(errors) > 0 {
+		return ProvenanceResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProvenanceResponseMultiError is an error wrapping multiple validation errors
+// returned by ProvenanceResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ProvenanceResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProvenanceResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProvenanceResponseMultiError) AllErrors() []error { return m }
+
+// ProvenanceResponseValidationError is the validation error returned by
+// ProvenanceResponse.Validate if the designated constraints aren't met.
+type ProvenanceResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProvenanceResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProvenanceResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProvenanceResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProvenanceResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProvenanceResponseValidationError) ErrorName() string {
+	return "ProvenanceResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ProvenanceResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProvenanceResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProvenanceResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProvenanceResponseValidationError{}
+
+// Validate checks the field values on AttestationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AttestationRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AttestationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AttestationRequestMultiError, or nil if none found.
+func (m *AttestationRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AttestationRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Nonce
+
+	if len(errors) > 0 {
+		return AttestationRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttestationRequestMultiError is an error wrapping multiple validation errors
+// returned by AttestationRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AttestationRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttestationRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttestationRequestMultiError) AllErrors() []error { return m }
+
+// AttestationRequestValidationError is the validation error returned by
+// AttestationRequest.Validate if the designated constraints aren't met.
+type AttestationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttestationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttestationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttestationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttestationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttestationRequestValidationError) ErrorName() string {
+	return "AttestationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AttestationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttestationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttestationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttestationRequestValidationError{}
+
+// Validate checks the field values on AttestationResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AttestationResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AttestationResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AttestationResponseMultiError, or nil if none found.
+func (m *AttestationResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AttestationResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for File
+
+	if len(errors) > 0 {
+		return AttestationResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttestationResponseMultiError is an error wrapping multiple validation
+// errors returned by AttestationResponse.ValidateAll() if the designated
+// constraints aren't met.
+type AttestationResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttestationResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttestationResponseMultiError) AllErrors() []error { return m }
+
+// AttestationResponseValidationError is the validation error returned by
+// AttestationResponse.Validate if the designated constraints aren't met.
+type AttestationResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttestationResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttestationResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttestationResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttestationResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttestationResponseValidationError) ErrorName() string {
+	return "AttestationResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AttestationResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttestationResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttestationResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttestationResponseValidationError{}
+
+// Validate checks the field values on StatusRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StatusRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StatusRequestMultiError, or
+// nil if none found.
+func (m *StatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return StatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StatusRequestMultiError is an error wrapping multiple validation errors
+// returned by StatusRequest.ValidateAll() if the designated constraints
+// aren't met.
+type StatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StatusRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StatusRequestMultiError) AllErrors() []error { return m }
+
+// StatusRequestValidationError is the validation error returned by
+// StatusRequest.Validate if the designated constraints aren't met.
+type StatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StatusRequestValidationError) ErrorName() string { return "StatusRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StatusRequestValidationError{}
+
+// Validate checks the field values on StatusResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StatusResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StatusResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StatusResponseMultiError,
+// or nil if none found.
+func (m *StatusResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StatusResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for State
+
+	if all {
+		switch v := interface{}(m.GetStartTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "StartTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStartTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StatusResponseValidationError{
+				field:  "StartTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEndTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StatusResponseValidationError{
+				field:  "EndTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ExitCode
+
+	// no validation rules for Error
+
+	if all {
+		switch v := interface{}(m.GetWipeTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "WipeTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StatusResponseValidationError{
+					field:  "WipeTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWipeTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StatusResponseValidationError{
+				field:  "WipeTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return StatusResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// StatusResponseMultiError is an error wrapping multiple validation errors
+// returned by StatusResponse.ValidateAll() if the designated constraints
+// aren't met.
+type StatusResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StatusResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StatusResponseMultiError) AllErrors() []error { return m }
+
+// StatusResponseValidationError is the validation error returned by
+// StatusResponse.Validate if the designated constraints aren't met.
+type StatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StatusResponseValidationError) ErrorName() string { return "StatusResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StatusResponseValidationError{}
+
+// Validate checks the field values on LogsRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogsRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogsRequestMultiError, or
+// nil if none found.
+func (m *LogsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Follow
+
+	if len(errors) > 0 {
+		return LogsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogsRequestMultiError is an error wrapping multiple validation errors
+// returned by LogsRequest.ValidateAll() if the designated constraints aren't met.
+type LogsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogsRequestMultiError) AllErrors() []error { return m }
+
+// LogsRequestValidationError is the validation error returned by
+// LogsRequest.Validate if the designated constraints aren't met.
+type LogsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogsRequestValidationError) ErrorName() string { return "LogsRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogsRequestValidationError{}
+
+// Validate checks the field values on LogsResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LogsResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogsResponseMultiError, or
+// nil if none found.
+func (m *LogsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LogsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Stream
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LogsResponseValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LogsResponseValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LogsResponseValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return LogsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LogsResponseMultiError is an error wrapping multiple validation errors
+// returned by LogsResponse.ValidateAll() if the designated constraints aren't met.
+type LogsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LogsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LogsResponseMultiError) AllErrors() []error { return m }
+
+// LogsResponseValidationError is the validation error returned by
+// LogsResponse.Validate if the designated constraints aren't met.
+type LogsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LogsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LogsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LogsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LogsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LogsResponseValidationError) ErrorName() string { return "LogsResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LogsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LogsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LogsResponseValidationError{}
+
+// Validate checks the field values on SubscribeRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SubscribeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SubscribeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SubscribeRequestMultiError, or nil if none found.
+func (m *SubscribeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SubscribeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return SubscribeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SubscribeRequestMultiError is an error wrapping multiple validation errors
+// returned by SubscribeRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SubscribeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SubscribeRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SubscribeRequestMultiError) AllErrors() []error { return m }
+
+// SubscribeRequestValidationError is the validation error returned by
+// SubscribeRequest.Validate if the designated constraints aren't met.
+type SubscribeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SubscribeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SubscribeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SubscribeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SubscribeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SubscribeRequestValidationError) ErrorName() string { return "SubscribeRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SubscribeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSubscribeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SubscribeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SubscribeRequestValidationError{}
+
+// Validate checks the field values on ComputationEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ComputationEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ComputationEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ComputationEventMultiError, or nil if none found.
+func (m *ComputationEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ComputationEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Type
+
+	// no validation rules for ComputationId
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ComputationEventValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ComputationEventValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ComputationEventValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ArtifactId
+
+	// no validation rules for Step
+
+	// no validation rules for Steps
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return ComputationEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ComputationEventMultiError is an error wrapping multiple validation errors
+// returned by ComputationEvent.ValidateAll() if the designated constraints
+// aren't met.
+type ComputationEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ComputationEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ComputationEventMultiError) AllErrors() []error { return m }
+
+// ComputationEventValidationError is the validation error returned by
+// ComputationEvent.Validate if the designated constraints aren't met.
+type ComputationEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ComputationEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ComputationEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ComputationEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ComputationEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ComputationEventValidationError) ErrorName() string { return "ComputationEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ComputationEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sComputationEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ComputationEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ComputationEventValidationError{}
@@ -19,11 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AgentService_Run_FullMethodName         = "/agent.AgentService/Run"
-	AgentService_Algo_FullMethodName        = "/agent.AgentService/Algo"
-	AgentService_Data_FullMethodName        = "/agent.AgentService/Data"
-	AgentService_Result_FullMethodName      = "/agent.AgentService/Result"
-	AgentService_Attestation_FullMethodName = "/agent.AgentService/Attestation"
+	AgentService_Run_FullMethodName             = "/agent.AgentService/Run"
+	AgentService_Algo_FullMethodName            = "/agent.AgentService/Algo"
+	AgentService_Data_FullMethodName            = "/agent.AgentService/Data"
+	AgentService_UploadAlgorithm_FullMethodName = "/agent.AgentService/UploadAlgorithm"
+	AgentService_UploadDataset_FullMethodName   = "/agent.AgentService/UploadDataset"
+	AgentService_UploadProgress_FullMethodName  = "/agent.AgentService/UploadProgress"
+	AgentService_ProvideKey_FullMethodName      = "/agent.AgentService/ProvideKey"
+	AgentService_Result_FullMethodName          = "/agent.AgentService/Result"
+	AgentService_Provenance_FullMethodName      = "/agent.AgentService/Provenance"
+	AgentService_Attestation_FullMethodName     = "/agent.AgentService/Attestation"
+	AgentService_Status_FullMethodName          = "/agent.AgentService/Status"
+	AgentService_Logs_FullMethodName            = "/agent.AgentService/Logs"
+	AgentService_Subscribe_FullMethodName       = "/agent.AgentService/Subscribe"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -33,8 +41,16 @@ type AgentServiceClient interface {
 	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*RunResponse, error)
 	Algo(ctx context.Context, in *AlgoRequest, opts ...grpc.CallOption) (*AlgoResponse, error)
 	Data(ctx context.Context, in *DataRequest, opts ...grpc.CallOption) (*DataResponse, error)
+	UploadAlgorithm(ctx context.Context, opts ...grpc.CallOption) (AgentService_UploadAlgorithmClient, error)
+	UploadDataset(ctx context.Context, opts ...grpc.CallOption) (AgentService_UploadDatasetClient, error)
+	UploadProgress(ctx context.Context, in *UploadProgressRequest, opts ...grpc.CallOption) (*UploadResponse, error)
+	ProvideKey(ctx context.Context, in *ProvideKeyRequest, opts ...grpc.CallOption) (*ProvideKeyResponse, error)
 	Result(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultResponse, error)
+	Provenance(ctx context.Context, in *ProvenanceRequest, opts ...grpc.CallOption) (*ProvenanceResponse, error)
 	Attestation(ctx context.Context, in *AttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (AgentService_LogsClient, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (AgentService_SubscribeClient, error)
 }
 
 type agentServiceClient struct {
@@ -72,6 +88,92 @@ func (c *agentServiceClient) Data(ctx context.Context, in *DataRequest, opts ...
 	return out, nil
 }
 
+func (c *agentServiceClient) UploadAlgorithm(ctx context.Context, opts ...grpc.CallOption) (AgentService_UploadAlgorithmClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[0], AgentService_UploadAlgorithm_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentServiceUploadAlgorithmClient{stream}
+	return x, nil
+}
+
+type AgentService_UploadAlgorithmClient interface {
+	Send(*UploadRequest) error
+	CloseAndRecv() (*UploadResponse, error)
+	grpc.ClientStream
+}
+
+type agentServiceUploadAlgorithmClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentServiceUploadAlgorithmClient) Send(m *UploadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentServiceUploadAlgorithmClient) CloseAndRecv() (*UploadResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentServiceClient) UploadDataset(ctx context.Context, opts ...grpc.CallOption) (AgentService_UploadDatasetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_UploadDataset_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentServiceUploadDatasetClient{stream}
+	return x, nil
+}
+
+type AgentService_UploadDatasetClient interface {
+	Send(*UploadRequest) error
+	CloseAndRecv() (*UploadResponse, error)
+	grpc.ClientStream
+}
+
+type agentServiceUploadDatasetClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentServiceUploadDatasetClient) Send(m *UploadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentServiceUploadDatasetClient) CloseAndRecv() (*UploadResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentServiceClient) UploadProgress(ctx context.Context, in *UploadProgressRequest, opts ...grpc.CallOption) (*UploadResponse, error) {
+	out := new(UploadResponse)
+	err := c.cc.Invoke(ctx, AgentService_UploadProgress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ProvideKey(ctx context.Context, in *ProvideKeyRequest, opts ...grpc.CallOption) (*ProvideKeyResponse, error) {
+	out := new(ProvideKeyResponse)
+	err := c.cc.Invoke(ctx, AgentService_ProvideKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentServiceClient) Result(ctx context.Context, in *ResultRequest, opts ...grpc.CallOption) (*ResultResponse, error) {
 	out := new(ResultResponse)
 	err := c.cc.Invoke(ctx, AgentService_Result_FullMethodName, in, out, opts...)
@@ -81,6 +183,15 @@ func (c *agentServiceClient) Result(ctx context.Context, in *ResultRequest, opts
 	return out, nil
 }
 
+func (c *agentServiceClient) Provenance(ctx context.Context, in *ProvenanceRequest, opts ...grpc.CallOption) (*ProvenanceResponse, error) {
+	out := new(ProvenanceResponse)
+	err := c.cc.Invoke(ctx, AgentService_Provenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentServiceClient) Attestation(ctx context.Context, in *AttestationRequest, opts ...grpc.CallOption) (*AttestationResponse, error) {
 	out := new(AttestationResponse)
 	err := c.cc.Invoke(ctx, AgentService_Attestation_FullMethodName, in, out, opts...)
@@ -90,6 +201,79 @@ func (c *agentServiceClient) Attestation(ctx context.Context, in *AttestationReq
 	return out, nil
 }
 
+func (c *agentServiceClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, AgentService_Status_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (AgentService_LogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[2], AgentService_Logs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentServiceLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AgentService_LogsClient interface {
+	Recv() (*LogsResponse, error)
+	grpc.ClientStream
+}
+
+type agentServiceLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentServiceLogsClient) Recv() (*LogsResponse, error) {
+	m := new(LogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (AgentService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[3], AgentService_Subscribe_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AgentService_SubscribeClient interface {
+	Recv() (*ComputationEvent, error)
+	grpc.ClientStream
+}
+
+type agentServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentServiceSubscribeClient) Recv() (*ComputationEvent, error) {
+	m := new(ComputationEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility
@@ -97,8 +281,16 @@ type AgentServiceServer interface {
 	Run(context.Context, *RunRequest) (*RunResponse, error)
 	Algo(context.Context, *AlgoRequest) (*AlgoResponse, error)
 	Data(context.Context, *DataRequest) (*DataResponse, error)
+	UploadAlgorithm(AgentService_UploadAlgorithmServer) error
+	UploadDataset(AgentService_UploadDatasetServer) error
+	UploadProgress(context.Context, *UploadProgressRequest) (*UploadResponse, error)
+	ProvideKey(context.Context, *ProvideKeyRequest) (*ProvideKeyResponse, error)
 	Result(context.Context, *ResultRequest) (*ResultResponse, error)
+	Provenance(context.Context, *ProvenanceRequest) (*ProvenanceResponse, error)
 	Attestation(context.Context, *AttestationRequest) (*AttestationResponse, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	Logs(*LogsRequest, AgentService_LogsServer) error
+	Subscribe(*SubscribeRequest, AgentService_SubscribeServer) error
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -115,12 +307,36 @@ func (UnimplementedAgentServiceServer) Algo(context.Context, *AlgoRequest) (*Alg
 func (UnimplementedAgentServiceServer) Data(context.Context, *DataRequest) (*DataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Data not implemented")
 }
+func (UnimplementedAgentServiceServer) UploadAlgorithm(AgentService_UploadAlgorithmServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAlgorithm not implemented")
+}
+func (UnimplementedAgentServiceServer) UploadDataset(AgentService_UploadDatasetServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadDataset not implemented")
+}
+func (UnimplementedAgentServiceServer) UploadProgress(context.Context, *UploadProgressRequest) (*UploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadProgress not implemented")
+}
+func (UnimplementedAgentServiceServer) ProvideKey(context.Context, *ProvideKeyRequest) (*ProvideKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvideKey not implemented")
+}
 func (UnimplementedAgentServiceServer) Result(context.Context, *ResultRequest) (*ResultResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Result not implemented")
 }
+func (UnimplementedAgentServiceServer) Provenance(context.Context, *ProvenanceRequest) (*ProvenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Provenance not implemented")
+}
 func (UnimplementedAgentServiceServer) Attestation(context.Context, *AttestationRequest) (*AttestationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Attestation not implemented")
 }
+func (UnimplementedAgentServiceServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedAgentServiceServer) Logs(*LogsRequest, AgentService_LogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Logs not implemented")
+}
+func (UnimplementedAgentServiceServer) Subscribe(*SubscribeRequest, AgentService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 
 // UnsafeAgentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -188,6 +404,94 @@ func _AgentService_Data_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_UploadAlgorithm_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).UploadAlgorithm(&agentServiceUploadAlgorithmServer{stream})
+}
+
+type AgentService_UploadAlgorithmServer interface {
+	SendAndClose(*UploadResponse) error
+	Recv() (*UploadRequest, error)
+	grpc.ServerStream
+}
+
+type agentServiceUploadAlgorithmServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentServiceUploadAlgorithmServer) SendAndClose(m *UploadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentServiceUploadAlgorithmServer) Recv() (*UploadRequest, error) {
+	m := new(UploadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AgentService_UploadDataset_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServiceServer).UploadDataset(&agentServiceUploadDatasetServer{stream})
+}
+
+type AgentService_UploadDatasetServer interface {
+	SendAndClose(*UploadResponse) error
+	Recv() (*UploadRequest, error)
+	grpc.ServerStream
+}
+
+type agentServiceUploadDatasetServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentServiceUploadDatasetServer) SendAndClose(m *UploadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentServiceUploadDatasetServer) Recv() (*UploadRequest, error) {
+	m := new(UploadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AgentService_UploadProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).UploadProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_UploadProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).UploadProgress(ctx, req.(*UploadProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ProvideKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvideKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ProvideKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ProvideKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ProvideKey(ctx, req.(*ProvideKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AgentService_Result_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResultRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +510,24 @@ func _AgentService_Result_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_Provenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Provenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Provenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Provenance(ctx, req.(*ProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AgentService_Attestation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AttestationRequest)
 	if err := dec(in); err != nil {
@@ -224,6 +546,66 @@ func _AgentService_Attestation_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).Logs(m, &agentServiceLogsServer{stream})
+}
+
+type AgentService_LogsServer interface {
+	Send(*LogsResponse) error
+	grpc.ServerStream
+}
+
+type agentServiceLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentServiceLogsServer) Send(m *LogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AgentService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).Subscribe(m, &agentServiceSubscribeServer{stream})
+}
+
+type AgentService_SubscribeServer interface {
+	Send(*ComputationEvent) error
+	grpc.ServerStream
+}
+
+type agentServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentServiceSubscribeServer) Send(m *ComputationEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,15 +625,52 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Data",
 			Handler:    _AgentService_Data_Handler,
 		},
+		{
+			MethodName: "UploadProgress",
+			Handler:    _AgentService_UploadProgress_Handler,
+		},
+		{
+			MethodName: "ProvideKey",
+			Handler:    _AgentService_ProvideKey_Handler,
+		},
 		{
 			MethodName: "Result",
 			Handler:    _AgentService_Result_Handler,
 		},
+		{
+			MethodName: "Provenance",
+			Handler:    _AgentService_Provenance_Handler,
+		},
 		{
 			MethodName: "Attestation",
 			Handler:    _AgentService_Attestation_Handler,
 		},
+		{
+			MethodName: "Status",
+			Handler:    _AgentService_Status_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAlgorithm",
+			Handler:       _AgentService_UploadAlgorithm_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadDataset",
+			Handler:       _AgentService_UploadDataset_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Logs",
+			Handler:       _AgentService_Logs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _AgentService_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "agent/agent.proto",
 }
@@ -9,17 +9,25 @@ import (
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/ultravioletrs/agent/agent"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const svcName = "agent.AgentService"
 
 type grpcClient struct {
-	run         endpoint.Endpoint
-	algo        endpoint.Endpoint
-	data        endpoint.Endpoint
-	result      endpoint.Endpoint
-	attestation endpoint.Endpoint
-	timeout     time.Duration
+	run            endpoint.Endpoint
+	algo           endpoint.Endpoint
+	data           endpoint.Endpoint
+	uploadProgress endpoint.Endpoint
+	provideKey     endpoint.Endpoint
+	result         endpoint.Endpoint
+	provenance     endpoint.Endpoint
+	attestation    endpoint.Endpoint
+	status         endpoint.Endpoint
+	// streams is the generated client used for the streaming RPCs, which
+	// go-kit endpoints cannot express.
+	streams agent.AgentServiceClient
+	timeout time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -49,6 +57,22 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) agent.AgentServiceC
 			decodeDataResponse,
 			agent.DataResponse{},
 		).Endpoint(),
+		uploadProgress: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"UploadProgress",
+			encodeUploadProgressRequest,
+			decodeUploadResponse,
+			agent.UploadResponse{},
+		).Endpoint(),
+		provideKey: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ProvideKey",
+			encodeProvideKeyRequest,
+			decodeProvideKeyResponse,
+			agent.ProvideKeyResponse{},
+		).Endpoint(),
 		result: kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -57,6 +81,14 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) agent.AgentServiceC
 			decodeResultResponse,
 			agent.ResultResponse{},
 		).Endpoint(),
+		provenance: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Provenance",
+			encodeProvenanceRequest,
+			decodeProvenanceResponse,
+			agent.ProvenanceResponse{},
+		).Endpoint(),
 		attestation: kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -65,6 +97,15 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) agent.AgentServiceC
 			decodeAttestationResponse,
 			agent.AttestationResponse{},
 		).Endpoint(),
+		status: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Status",
+			encodeStatusRequest,
+			decodeStatusResponse,
+			agent.StatusResponse{},
+		).Endpoint(),
+		streams: agent.NewAgentServiceClient(conn),
 		timeout: timeout,
 	}
 }
@@ -104,6 +145,7 @@ func encodeAlgoRequest(_ context.Context, request interface{}) (interface{}, err
 
 	return &agent.AlgoRequest{
 		Algorithm: req.Algorithm,
+		Id:        req.ID,
 	}, nil
 }
 
@@ -130,6 +172,7 @@ func encodeDataRequest(_ context.Context, request interface{}) (interface{}, err
 
 	return &agent.DataRequest{
 		Dataset: req.Dataset,
+		Id:      req.ID,
 	}, nil
 }
 
@@ -146,6 +189,59 @@ func decodeDataResponse(_ context.Context, grpcResponse interface{}) (interface{
 	}, nil
 }
 
+// encodeUploadProgressRequest is a transport/grpc.EncodeRequestFunc that
+// converts a user-domain uploadProgressReq to a gRPC request.
+func encodeUploadProgressRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*uploadProgressReq)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type: %T", request)
+	}
+
+	return &agent.UploadProgressRequest{
+		Id: req.ID,
+	}, nil
+}
+
+// decodeUploadResponse is a transport/grpc.DecodeResponseFunc that
+// converts a gRPC UploadResponse to a user-domain response.
+func decodeUploadResponse(_ context.Context, grpcResponse interface{}) (interface{}, error) {
+	response, ok := grpcResponse.(*agent.UploadResponse)
+	if !ok {
+		return nil, fmt.Errorf("invalid response type: %T", grpcResponse)
+	}
+
+	return uploadRes{
+		ID:       response.Id,
+		Size:     response.Size,
+		Received: response.Received,
+		Complete: response.Complete,
+	}, nil
+}
+
+// encodeProvideKeyRequest is a transport/grpc.EncodeRequestFunc that
+// converts a user-domain provideKeyReq to a gRPC request.
+func encodeProvideKeyRequest(_ context.Context, request interface{}) (interface{}, error) {
+	req, ok := request.(*provideKeyReq)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type: %T", request)
+	}
+
+	return &agent.ProvideKeyRequest{
+		Id:  req.ID,
+		Key: req.Key,
+	}, nil
+}
+
+// decodeProvideKeyResponse is a transport/grpc.DecodeResponseFunc that
+// converts a gRPC ProvideKeyResponse to a user-domain response.
+func decodeProvideKeyResponse(_ context.Context, grpcResponse interface{}) (interface{}, error) {
+	if _, ok := grpcResponse.(*agent.ProvideKeyResponse); !ok {
+		return nil, fmt.Errorf("invalid response type: %T", grpcResponse)
+	}
+
+	return provideKeyRes{}, nil
+}
+
 // encodeResultRequest is a transport/grpc.EncodeRequestFunc that
 // converts a user-domain resultReq to a gRPC request.
 func encodeResultRequest(_ context.Context, request interface{}) (interface{}, error) {
@@ -166,11 +262,37 @@ func decodeResultResponse(_ context.Context, grpcResponse interface{}) (interfac
 	}, nil
 }
 
+// encodeProvenanceRequest is a transport/grpc.EncodeRequestFunc that
+// converts a user-domain provenanceReq to a gRPC request.
+func encodeProvenanceRequest(_ context.Context, request interface{}) (interface{}, error) {
+	// No request parameters needed for retrieving the provenance document
+	return &agent.ProvenanceRequest{}, nil
+}
+
+// decodeProvenanceResponse is a transport/grpc.DecodeResponseFunc that
+// converts a gRPC ProvenanceResponse to a user-domain response.
+func decodeProvenanceResponse(_ context.Context, grpcResponse interface{}) (interface{}, error) {
+	response, ok := grpcResponse.(*agent.ProvenanceResponse)
+	if !ok {
+		return nil, fmt.Errorf("invalid response type: %T", grpcResponse)
+	}
+
+	return provenanceRes{
+		File: response.File,
+	}, nil
+}
+
 // encodeAttestationRequest is a transport/grpc.EncodeRequestFunc that
 // converts a user-domain attestationReq to a gRPC request.
 func encodeAttestationRequest(_ context.Context, request interface{}) (interface{}, error) {
-	// No request parameters needed for retrieving computation result file
-	return &agent.AttestationRequest{}, nil
+	req, ok := request.(*attestationReq)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type: %T", request)
+	}
+
+	return &agent.AttestationRequest{
+		Nonce: req.Nonce,
+	}, nil
 }
 
 // decodeAttestationResponse is a transport/grpc.DecodeResponseFunc that
@@ -186,6 +308,39 @@ func decodeAttestationResponse(_ context.Context, grpcResponse interface{}) (int
 	}, nil
 }
 
+// encodeStatusRequest is a transport/grpc.EncodeRequestFunc that
+// converts a user-domain statusReq to a gRPC request.
+func encodeStatusRequest(_ context.Context, request interface{}) (interface{}, error) {
+	// No request parameters needed for retrieving computation status
+	return &agent.StatusRequest{}, nil
+}
+
+// decodeStatusResponse is a transport/grpc.DecodeResponseFunc that
+// converts a gRPC StatusResponse to a user-domain response.
+func decodeStatusResponse(_ context.Context, grpcResponse interface{}) (interface{}, error) {
+	response, ok := grpcResponse.(*agent.StatusResponse)
+	if !ok {
+		return nil, fmt.Errorf("invalid response type: %T", grpcResponse)
+	}
+
+	res := statusRes{
+		State:    response.State,
+		ExitCode: response.ExitCode,
+		Error:    response.Error,
+	}
+	if response.StartTime != nil {
+		res.StartTime = response.StartTime.AsTime()
+	}
+	if response.EndTime != nil {
+		res.EndTime = response.EndTime.AsTime()
+	}
+	if response.WipeTime != nil {
+		res.WipeTime = response.WipeTime.AsTime()
+	}
+
+	return res, nil
+}
+
 // Run implements the Run method of the agent.AgentServiceClient interface.
 func (c grpcClient) Run(ctx context.Context, request *agent.RunRequest, _ ...grpc.CallOption) (*agent.RunResponse, error) {
 	ctx, close := context.WithTimeout(ctx, c.timeout)
@@ -205,7 +360,7 @@ func (c grpcClient) Algo(ctx context.Context, request *agent.AlgoRequest, _ ...g
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	res, err := c.algo(ctx, &algoReq{Algorithm: request.Algorithm})
+	res, err := c.algo(ctx, &algoReq{Algorithm: request.Algorithm, ID: request.Id})
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +374,7 @@ func (c grpcClient) Data(ctx context.Context, request *agent.DataRequest, _ ...g
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	res, err := c.data(ctx, &dataReq{Dataset: request.Dataset})
+	res, err := c.data(ctx, &dataReq{Dataset: request.Dataset, ID: request.Id})
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +383,52 @@ func (c grpcClient) Data(ctx context.Context, request *agent.DataRequest, _ ...g
 	return &agent.DataResponse{DatasetID: dataRes.DatasetID}, nil
 }
 
+// UploadAlgorithm implements the UploadAlgorithm method of the
+// agent.AgentServiceClient interface. The stream is not bound by the client
+// timeout, as uploads of large algorithms may take long.
+func (c grpcClient) UploadAlgorithm(ctx context.Context, opts ...grpc.CallOption) (agent.AgentService_UploadAlgorithmClient, error) {
+	return c.streams.UploadAlgorithm(ctx, opts...)
+}
+
+// UploadDataset implements the UploadDataset method of the
+// agent.AgentServiceClient interface. The stream is not bound by the client
+// timeout, as uploads of large datasets may take long.
+func (c grpcClient) UploadDataset(ctx context.Context, opts ...grpc.CallOption) (agent.AgentService_UploadDatasetClient, error) {
+	return c.streams.UploadDataset(ctx, opts...)
+}
+
+// UploadProgress implements the UploadProgress method of the
+// agent.AgentServiceClient interface.
+func (c grpcClient) UploadProgress(ctx context.Context, request *agent.UploadProgressRequest, _ ...grpc.CallOption) (*agent.UploadResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.uploadProgress(ctx, &uploadProgressReq{ID: request.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	ur := res.(uploadRes)
+	return &agent.UploadResponse{
+		Id:       ur.ID,
+		Size:     ur.Size,
+		Received: ur.Received,
+		Complete: ur.Complete,
+	}, nil
+}
+
+// ProvideKey implements the ProvideKey method of the agent.AgentServiceClient interface.
+func (c grpcClient) ProvideKey(ctx context.Context, request *agent.ProvideKeyRequest, _ ...grpc.CallOption) (*agent.ProvideKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if _, err := c.provideKey(ctx, &provideKeyReq{ID: request.Id, Key: request.Key}); err != nil {
+		return nil, err
+	}
+
+	return &agent.ProvideKeyResponse{}, nil
+}
+
 // Result implements the Result method of the agent.AgentServiceClient interface.
 func (c grpcClient) Result(ctx context.Context, request *agent.ResultRequest, _ ...grpc.CallOption) (*agent.ResultResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
@@ -242,12 +443,26 @@ func (c grpcClient) Result(ctx context.Context, request *agent.ResultRequest, _
 	return &agent.ResultResponse{File: resultRes.File}, nil
 }
 
-// Result implements the Result method of the agent.AgentServiceClient interface.
+// Provenance implements the Provenance method of the agent.AgentServiceClient interface.
+func (c grpcClient) Provenance(ctx context.Context, request *agent.ProvenanceRequest, _ ...grpc.CallOption) (*agent.ProvenanceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.provenance(ctx, &provenanceReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	provenanceRes := res.(provenanceRes)
+	return &agent.ProvenanceResponse{File: provenanceRes.File}, nil
+}
+
+// Attestation implements the Attestation method of the agent.AgentServiceClient interface.
 func (c grpcClient) Attestation(ctx context.Context, request *agent.AttestationRequest, _ ...grpc.CallOption) (*agent.AttestationResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	res, err := c.result(ctx, &resultReq{})
+	res, err := c.attestation(ctx, &attestationReq{Nonce: request.Nonce})
 	if err != nil {
 		return nil, err
 	}
@@ -255,3 +470,46 @@ func (c grpcClient) Attestation(ctx context.Context, request *agent.AttestationR
 	attestationRes := res.(attestationRes)
 	return &agent.AttestationResponse{File: attestationRes.File}, nil
 }
+
+// Status implements the Status method of the agent.AgentServiceClient interface.
+func (c grpcClient) Status(ctx context.Context, request *agent.StatusRequest, _ ...grpc.CallOption) (*agent.StatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.status(ctx, &statusReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	statusRes := res.(statusRes)
+	sr := &agent.StatusResponse{
+		State:    statusRes.State,
+		ExitCode: statusRes.ExitCode,
+		Error:    statusRes.Error,
+	}
+	if !statusRes.StartTime.IsZero() {
+		sr.StartTime = timestamppb.New(statusRes.StartTime)
+	}
+	if !statusRes.EndTime.IsZero() {
+		sr.EndTime = timestamppb.New(statusRes.EndTime)
+	}
+	if !statusRes.WipeTime.IsZero() {
+		sr.WipeTime = timestamppb.New(statusRes.WipeTime)
+	}
+
+	return sr, nil
+}
+
+// Logs implements the Logs method of the agent.AgentServiceClient interface.
+// The client timeout does not apply since the stream lasts as long as the
+// computation when following the logs.
+func (c grpcClient) Logs(ctx context.Context, request *agent.LogsRequest, opts ...grpc.CallOption) (agent.AgentService_LogsClient, error) {
+	return c.streams.Logs(ctx, request, opts...)
+}
+
+// Subscribe implements the Subscribe method of the agent.AgentServiceClient
+// interface. The client timeout does not apply since the stream lasts as
+// long as the computation.
+func (c grpcClient) Subscribe(ctx context.Context, request *agent.SubscribeRequest, opts ...grpc.CallOption) (agent.AgentService_SubscribeClient, error) {
+	return c.streams.Subscribe(ctx, request, opts...)
+}
@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/ultravioletrs/agent/agent"
@@ -16,18 +15,16 @@ func runEndpoint(svc agent.Service) endpoint.Endpoint {
 			return runRes{}, err
 		}
 
-		var computation agent.Computation
-		err := json.Unmarshal(req.Computation, &computation)
+		cmp, err := svc.Run(ctx, agent.ManifestToComputation(req.Computation))
 		if err != nil {
-			return nil, err
+			return runRes{}, err
 		}
-
-		computationStr, err := svc.Run(ctx, computation)
+		manifest, err := agent.ComputationToManifest(cmp)
 		if err != nil {
 			return runRes{}, err
 		}
 
-		return runRes{Computation: computationStr}, nil
+		return runRes{Computation: manifest}, nil
 	}
 }
 
@@ -39,7 +36,7 @@ func algoEndpoint(svc agent.Service) endpoint.Endpoint {
 			return algoRes{}, err
 		}
 
-		algorithmID, err := svc.Algo(ctx, req.Algorithm)
+		algorithmID, err := svc.Algo(ctx, agent.Artifact{Content: req.Algorithm, ID: req.ID})
 		if err != nil {
 			return algoRes{}, err
 		}
@@ -56,7 +53,7 @@ func dataEndpoint(svc agent.Service) endpoint.Endpoint {
 			return dataRes{}, err
 		}
 
-		datasetID, err := svc.Data(ctx, req.Dataset)
+		datasetID, err := svc.Data(ctx, agent.Artifact{Content: req.Dataset, ID: req.ID})
 		if err != nil {
 			return dataRes{}, err
 		}
@@ -65,6 +62,44 @@ func dataEndpoint(svc agent.Service) endpoint.Endpoint {
 	}
 }
 
+func uploadProgressEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(uploadProgressReq)
+
+		if err := req.validate(); err != nil {
+			return uploadRes{}, err
+		}
+
+		progress, err := svc.UploadProgress(ctx, req.ID)
+		if err != nil {
+			return uploadRes{}, err
+		}
+
+		return uploadRes{
+			ID:       progress.ID,
+			Size:     progress.Size,
+			Received: progress.Received,
+			Complete: progress.Complete,
+		}, nil
+	}
+}
+
+func provideKeyEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(provideKeyReq)
+
+		if err := req.validate(); err != nil {
+			return provideKeyRes{}, err
+		}
+
+		if err := svc.ProvideKey(ctx, req.ID, req.Key); err != nil {
+			return provideKeyRes{}, err
+		}
+
+		return provideKeyRes{}, nil
+	}
+}
+
 func resultEndpoint(svc agent.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(resultReq)
@@ -81,6 +116,22 @@ func resultEndpoint(svc agent.Service) endpoint.Endpoint {
 	}
 }
 
+func provenanceEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(provenanceReq)
+
+		if err := req.validate(); err != nil {
+			return provenanceRes{}, err
+		}
+		file, err := svc.Provenance(ctx)
+		if err != nil {
+			return provenanceRes{}, err
+		}
+
+		return provenanceRes{File: file}, nil
+	}
+}
+
 func attestationEndpoint(svc agent.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(attestationReq)
@@ -88,7 +139,7 @@ func attestationEndpoint(svc agent.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return attestationRes{}, err
 		}
-		file, err := svc.Attestation(ctx)
+		file, err := svc.Attestation(ctx, req.Nonce)
 		if err != nil {
 			return attestationRes{}, err
 		}
@@ -96,3 +147,27 @@ func attestationEndpoint(svc agent.Service) endpoint.Endpoint {
 		return attestationRes{File: file}, nil
 	}
 }
+
+func statusEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(statusReq)
+
+		if err := req.validate(); err != nil {
+			return statusRes{}, err
+		}
+
+		status, err := svc.Status(ctx)
+		if err != nil {
+			return statusRes{}, err
+		}
+
+		return statusRes{
+			State:     status.State.String(),
+			StartTime: status.StartTime,
+			EndTime:   status.EndTime,
+			ExitCode:  int32(status.ExitCode),
+			Error:     status.Error,
+			WipeTime:  status.WipeTime,
+		}, nil
+	}
+}
@@ -1,36 +1,69 @@
 package grpc
 
-import "errors"
+import (
+	"fmt"
+
+	"github.com/ultravioletrs/agent/agent"
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
 
 type runReq struct {
-	Computation []byte `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Computation *agent.ComputationManifest
 }
 
 func (req runReq) validate() error {
-	if len(req.Computation) == 0 {
-		return errors.New("algorithm binary is required")
+	if req.Computation == nil {
+		return fmt.Errorf("%w: computation manifest is required", agent.ErrMalformedEntity)
 	}
 	return nil
 }
 
 type algoReq struct {
 	Algorithm []byte `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	ID        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (req algoReq) validate() error {
 	if len(req.Algorithm) == 0 {
-		return errors.New("algorithm binary is required")
+		return fmt.Errorf("%w: algorithm binary is required", agent.ErrMalformedEntity)
 	}
 	return nil
 }
 
 type dataReq struct {
 	Dataset []byte `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	ID      string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (req dataReq) validate() error {
 	if len(req.Dataset) == 0 {
-		return errors.New("dataset CSV file is required")
+		return fmt.Errorf("%w: dataset CSV file is required", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type uploadProgressReq struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (req uploadProgressReq) validate() error {
+	if req.ID == "" {
+		return fmt.Errorf("%w: artifact ID is required", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type provideKeyReq struct {
+	ID  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (req provideKeyReq) validate() error {
+	if req.ID == "" {
+		return fmt.Errorf("%w: artifact ID is required", agent.ErrMalformedEntity)
+	}
+	if len(req.Key) == 0 {
+		return fmt.Errorf("%w: key is required", agent.ErrMalformedEntity)
 	}
 	return nil
 }
@@ -44,11 +77,31 @@ func (req resultReq) validate() error {
 	return nil
 }
 
+type provenanceReq struct {
+	// No request parameters needed for retrieving the provenance document
+}
+
+func (req provenanceReq) validate() error {
+	// No request parameters to validate, so no validation logic needed
+	return nil
+}
+
 type attestationReq struct {
-	// No request parameters needed for retrieving computation result file
+	Nonce []byte `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 }
 
 func (req attestationReq) validate() error {
+	if len(req.Nonce) != attestation.NonceSize {
+		return fmt.Errorf("%w: nonce must be 32 bytes long", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type statusReq struct {
+	// No request parameters needed for retrieving computation status
+}
+
+func (req statusReq) validate() error {
 	// No request parameters to validate, so no validation logic needed
 	return nil
 }
@@ -1,7 +1,13 @@
 package grpc
 
+import (
+	"time"
+
+	"github.com/ultravioletrs/agent/agent"
+)
+
 type runRes struct {
-	Computation string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Computation *agent.ComputationManifest
 }
 
 type algoRes struct {
@@ -12,10 +18,32 @@ type dataRes struct {
 	DatasetID string `json:"datasetId,omitempty"`
 }
 
+type uploadRes struct {
+	ID       string
+	Size     int64
+	Received int64
+	Complete bool
+}
+
+type provideKeyRes struct{}
+
 type resultRes struct {
 	File []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
+type provenanceRes struct {
+	File []byte
+}
+
 type attestationRes struct {
 	File []byte
 }
+
+type statusRes struct {
+	State     string
+	StartTime time.Time
+	EndTime   time.Time
+	ExitCode  int32
+	Error     string
+	WipeTime  time.Time
+}
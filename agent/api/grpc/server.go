@@ -5,45 +5,94 @@ import (
 
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/ultravioletrs/agent/agent"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type grpcServer struct {
-	run         kitgrpc.Handler
-	algo        kitgrpc.Handler
-	data        kitgrpc.Handler
-	result      kitgrpc.Handler
-	attestation kitgrpc.Handler
+	run            kitgrpc.Handler
+	algo           kitgrpc.Handler
+	data           kitgrpc.Handler
+	uploadProgress kitgrpc.Handler
+	provideKey     kitgrpc.Handler
+	result         kitgrpc.Handler
+	provenance     kitgrpc.Handler
+	attestation    kitgrpc.Handler
+	status         kitgrpc.Handler
+	svc            agent.Service
+	identify       kitgrpc.ServerRequestFunc
 	agent.UnimplementedAgentServiceServer
 }
 
-// NewServer returns new AgentServiceServer instance.
-func NewServer(svc agent.Service) agent.AgentServiceServer {
+// NewServer returns new AgentServiceServer instance. attestedTLS reports
+// whether the server serves the attested certificate of the agent, and
+// tlsPublicKey is the DER encoded public key of the certificate it serves.
+func NewServer(svc agent.Service, attestedTLS bool, tlsPublicKey []byte) agent.AgentServiceServer {
+	identify := identifier(attestedTLS, tlsPublicKey)
+	opts := []kitgrpc.ServerOption{
+		kitgrpc.ServerBefore(identify),
+	}
+
 	return &grpcServer{
 		run: kitgrpc.NewServer(
 			runEndpoint(svc),
 			decodeRunRequest,
 			encodeRunResponse,
+			opts...,
 		),
 		algo: kitgrpc.NewServer(
 			algoEndpoint(svc),
 			decodeAlgoRequest,
 			encodeAlgoResponse,
+			opts...,
 		),
 		data: kitgrpc.NewServer(
 			dataEndpoint(svc),
 			decodeDataRequest,
 			encodeDataResponse,
+			opts...,
+		),
+		uploadProgress: kitgrpc.NewServer(
+			uploadProgressEndpoint(svc),
+			decodeUploadProgressRequest,
+			encodeUploadResponse,
+			opts...,
+		),
+		provideKey: kitgrpc.NewServer(
+			provideKeyEndpoint(svc),
+			decodeProvideKeyRequest,
+			encodeProvideKeyResponse,
+			opts...,
 		),
 		result: kitgrpc.NewServer(
 			resultEndpoint(svc),
 			decodeResultRequest,
 			encodeResultResponse,
+			opts...,
+		),
+		provenance: kitgrpc.NewServer(
+			provenanceEndpoint(svc),
+			decodeProvenanceRequest,
+			encodeProvenanceResponse,
+			opts...,
 		),
 		attestation: kitgrpc.NewServer(
 			attestationEndpoint(svc),
 			decodeAttestationRequest,
 			encodeAttestationResponse,
+			opts...,
+		),
+		status: kitgrpc.NewServer(
+			statusEndpoint(svc),
+			decodeStatusRequest,
+			encodeStatusResponse,
+			opts...,
 		),
+		svc:      svc,
+		identify: identify,
 	}
 }
 
@@ -67,6 +116,7 @@ func decodeAlgoRequest(_ context.Context, grpcReq interface{}) (interface{}, err
 
 	return algoReq{
 		Algorithm: req.Algorithm,
+		ID:        req.Id,
 	}, nil
 }
 
@@ -82,6 +132,7 @@ func decodeDataRequest(_ context.Context, grpcReq interface{}) (interface{}, err
 
 	return dataReq{
 		Dataset: req.Dataset,
+		ID:      req.Id,
 	}, nil
 }
 
@@ -92,6 +143,37 @@ func encodeDataResponse(_ context.Context, response interface{}) (interface{}, e
 	}, nil
 }
 
+func decodeUploadProgressRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*agent.UploadProgressRequest)
+
+	return uploadProgressReq{
+		ID: req.Id,
+	}, nil
+}
+
+func encodeUploadResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(uploadRes)
+	return &agent.UploadResponse{
+		Id:       res.ID,
+		Size:     res.Size,
+		Received: res.Received,
+		Complete: res.Complete,
+	}, nil
+}
+
+func decodeProvideKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*agent.ProvideKeyRequest)
+
+	return provideKeyReq{
+		ID:  req.Id,
+		Key: req.Key,
+	}, nil
+}
+
+func encodeProvideKeyResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return &agent.ProvideKeyResponse{}, nil
+}
+
 func decodeResultRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	// No fields to extract from gRPC request, so returning an empty struct
 	return resultReq{}, nil
@@ -104,9 +186,24 @@ func encodeResultResponse(_ context.Context, response interface{}) (interface{},
 	}, nil
 }
 
-func decodeAttestationRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+func decodeProvenanceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	// No fields to extract from gRPC request, so returning an empty struct
-	return attestationReq{}, nil
+	return provenanceReq{}, nil
+}
+
+func encodeProvenanceResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(provenanceRes)
+	return &agent.ProvenanceResponse{
+		File: res.File,
+	}, nil
+}
+
+func decodeAttestationRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*agent.AttestationRequest)
+
+	return attestationReq{
+		Nonce: req.Nonce,
+	}, nil
 }
 
 func encodeAttestationResponse(_ context.Context, response interface{}) (interface{}, error) {
@@ -116,6 +213,81 @@ func encodeAttestationResponse(_ context.Context, response interface{}) (interfa
 	}, nil
 }
 
+func decodeStatusRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	// No fields to extract from gRPC request, so returning an empty struct
+	return statusReq{}, nil
+}
+
+func encodeStatusResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res := response.(statusRes)
+	sr := &agent.StatusResponse{
+		State:    res.State,
+		ExitCode: res.ExitCode,
+		Error:    res.Error,
+	}
+	if !res.StartTime.IsZero() {
+		sr.StartTime = timestamppb.New(res.StartTime)
+	}
+	if !res.EndTime.IsZero() {
+		sr.EndTime = timestamppb.New(res.EndTime)
+	}
+	if !res.WipeTime.IsZero() {
+		sr.WipeTime = timestamppb.New(res.WipeTime)
+	}
+	return sr, nil
+}
+
+// identifier returns the request function adding the identities of the TLS
+// client, if it presented a certificate, and the public key of the server
+// certificate to the context. Requests received over TLS are marked as
+// attested when the server serves the attested certificate of the agent.
+func identifier(attestedTLS bool, tlsPublicKey []byte) kitgrpc.ServerRequestFunc {
+	return func(ctx context.Context, _ metadata.MD) context.Context {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return ctx
+		}
+		info, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok {
+			return ctx
+		}
+		if attestedTLS {
+			ctx = agent.WithAttestedTLS(ctx)
+		}
+		ctx = agent.WithTLSPublicKey(ctx, tlsPublicKey)
+
+		return agent.WithIdentities(ctx, agent.TLSIdentities(info.State))
+	}
+}
+
+// encodeError converts errors of the service to gRPC statuses with the
+// code of their kind.
+func encodeError(err error) error {
+	return agent.Status(err).Err()
+}
+
+// UnaryErrorInterceptor encodes the errors of the unary RPCs with
+// encodeError. The handlers return the errors of the service as they are,
+// so that every RPC encodes them alike.
+func UnaryErrorInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res, nil
+}
+
+// StreamErrorInterceptor encodes the errors of the streaming RPCs with
+// encodeError.
+func StreamErrorInterceptor(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, stream); err != nil {
+		return encodeError(err)
+	}
+
+	return nil
+}
+
 func (s *grpcServer) Run(ctx context.Context, req *agent.RunRequest) (*agent.RunResponse, error) {
 	_, res, err := s.run.ServeGRPC(ctx, req)
 	if err != nil {
@@ -143,6 +315,92 @@ func (s *grpcServer) Data(ctx context.Context, req *agent.DataRequest) (*agent.D
 	return dr, nil
 }
 
+// UploadAlgorithm and UploadDataset receive chunked uploads. They use the
+// service directly since go-kit handlers only support unary calls.
+func (s *grpcServer) UploadAlgorithm(stream agent.AgentService_UploadAlgorithmServer) error {
+	return s.upload(stream, s.svc.UploadAlgorithm)
+}
+
+func (s *grpcServer) UploadDataset(stream agent.AgentService_UploadDatasetServer) error {
+	return s.upload(stream, s.svc.UploadDataset)
+}
+
+// uploadStream is the server side of the UploadAlgorithm and UploadDataset
+// streams.
+type uploadStream interface {
+	Recv() (*agent.UploadRequest, error)
+	SendAndClose(*agent.UploadResponse) error
+	Context() context.Context
+}
+
+func (s *grpcServer) upload(stream uploadStream, upload func(context.Context, agent.Upload) (agent.UploadProgress, error)) error {
+	ctx := s.identify(stream.Context(), nil)
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	progress, err := upload(ctx, agent.Upload{
+		ID:      req.Id,
+		Size:    req.Size,
+		Offset:  req.Offset,
+		Content: &chunkReader{stream: stream, chunk: req.Chunk},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := encodeUploadResponse(ctx, uploadRes{
+		ID:       progress.ID,
+		Size:     progress.Size,
+		Received: progress.Received,
+		Complete: progress.Complete,
+	})
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(res.(*agent.UploadResponse))
+}
+
+// chunkReader reads the chunks of an upload stream.
+type chunkReader struct {
+	stream uploadStream
+	chunk  []byte
+}
+
+func (cr *chunkReader) Read(p []byte) (int, error) {
+	for len(cr.chunk) == 0 {
+		req, err := cr.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		cr.chunk = req.Chunk
+	}
+	n := copy(p, cr.chunk)
+	cr.chunk = cr.chunk[n:]
+
+	return n, nil
+}
+
+func (s *grpcServer) UploadProgress(ctx context.Context, req *agent.UploadProgressRequest) (*agent.UploadResponse, error) {
+	_, res, err := s.uploadProgress.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ur := res.(*agent.UploadResponse)
+	return ur, nil
+}
+
+func (s *grpcServer) ProvideKey(ctx context.Context, req *agent.ProvideKeyRequest) (*agent.ProvideKeyResponse, error) {
+	_, res, err := s.provideKey.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	pr := res.(*agent.ProvideKeyResponse)
+	return pr, nil
+}
+
 func (s *grpcServer) Result(ctx context.Context, req *agent.ResultRequest) (*agent.ResultResponse, error) {
 	_, res, err := s.result.ServeGRPC(ctx, req)
 	if err != nil {
@@ -152,6 +410,15 @@ func (s *grpcServer) Result(ctx context.Context, req *agent.ResultRequest) (*age
 	return rr, nil
 }
 
+func (s *grpcServer) Provenance(ctx context.Context, req *agent.ProvenanceRequest) (*agent.ProvenanceResponse, error) {
+	_, res, err := s.provenance.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	pr := res.(*agent.ProvenanceResponse)
+	return pr, nil
+}
+
 func (s *grpcServer) Attestation(ctx context.Context, req *agent.AttestationRequest) (*agent.AttestationResponse, error) {
 	_, res, err := s.attestation.ServeGRPC(ctx, req)
 	if err != nil {
@@ -160,3 +427,60 @@ func (s *grpcServer) Attestation(ctx context.Context, req *agent.AttestationRequ
 	rr := res.(*agent.AttestationResponse)
 	return rr, nil
 }
+
+func (s *grpcServer) Status(ctx context.Context, req *agent.StatusRequest) (*agent.StatusResponse, error) {
+	_, res, err := s.status.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sr := res.(*agent.StatusResponse)
+	return sr, nil
+}
+
+// Logs streams the output of the algorithms. It uses the service directly
+// since go-kit handlers only support unary calls.
+func (s *grpcServer) Logs(req *agent.LogsRequest, stream agent.AgentService_LogsServer) error {
+	ctx := s.identify(stream.Context(), nil)
+	entries, err := s.svc.Logs(ctx, req.Follow)
+	if err != nil {
+		return err
+	}
+
+	for entry := range entries {
+		res := &agent.LogsResponse{
+			Stream: entry.Stream,
+			Time:   timestamppb.New(entry.Time),
+			Data:   entry.Data,
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (s *grpcServer) Subscribe(req *agent.SubscribeRequest, stream agent.AgentService_SubscribeServer) error {
+	ctx := s.identify(stream.Context(), nil)
+	events, err := s.svc.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		res := &agent.ComputationEvent{
+			Type:          agent.ComputationEvent_Type(agent.ComputationEvent_Type_value[event.Type.String()]),
+			ComputationId: event.ComputationID,
+			Time:          timestamppb.New(event.Time),
+			ArtifactId:    event.ArtifactID,
+			Step:          int32(event.Step),
+			Steps:         int32(event.Steps),
+			Error:         event.Error,
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
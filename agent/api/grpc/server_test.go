@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ultravioletrs/agent/agent"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errorCases = []struct {
+	desc   string
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{
+		desc: "no error",
+		code: codes.OK,
+	},
+	{
+		desc:   "wrapped malformed entity",
+		err:    fmt.Errorf("%w: missing ID", agent.ErrMalformedEntity),
+		code:   codes.InvalidArgument,
+		reason: "MALFORMED_ENTITY",
+	},
+	{
+		desc:   "unauthorized access",
+		err:    agent.ErrUnauthorizedAccess,
+		code:   codes.PermissionDenied,
+		reason: "UNAUTHORIZED",
+	},
+	{
+		desc:   "wrapped upload at wrong offset",
+		err:    fmt.Errorf("%w: expected offset 4", agent.ErrUploadOffset),
+		code:   codes.FailedPrecondition,
+		reason: "WRONG_STATE",
+	},
+	{
+		desc:   "result of timed out computation",
+		err:    fmt.Errorf("%w: %w", agent.ErrComputationFailed, agent.ErrTimeout),
+		code:   codes.ResourceExhausted,
+		reason: "LIMIT_EXCEEDED",
+	},
+	{
+		desc:   "unexpected error",
+		err:    errors.New("unexpected"),
+		code:   codes.Internal,
+		reason: "INTERNAL",
+	},
+	{
+		desc: "canceled context",
+		err:  fmt.Errorf("receiving chunk: %w", context.Canceled),
+		code: codes.Canceled,
+	},
+	{
+		desc: "exceeded context deadline",
+		err:  context.DeadlineExceeded,
+		code: codes.DeadlineExceeded,
+	},
+	{
+		desc: "error of a broken stream",
+		err:  status.Error(codes.Unavailable, "transport is closing"),
+		code: codes.Unavailable,
+	},
+}
+
+// checkStatus checks that err is the status of the error case.
+func checkStatus(t *testing.T, desc string, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Errorf("%s: expected status error got %v", desc, err)
+		return
+	}
+	if st.Code() != code {
+		t.Errorf("%s: expected code %s got %s", desc, code, st.Code())
+	}
+	var got string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == agent.ErrorDomain {
+			got = info.Reason
+		}
+	}
+	if got != reason {
+		t.Errorf("%s: expected reason %q got %q", desc, reason, got)
+	}
+}
+
+func TestUnaryErrorInterceptor(t *testing.T) {
+	for _, tc := range errorCases {
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			if tc.err != nil {
+				return nil, tc.err
+			}
+			return &agent.StatusResponse{}, nil
+		}
+		res, err := UnaryErrorInterceptor(context.Background(), &agent.StatusRequest{}, &grpc.UnaryServerInfo{}, handler)
+		if tc.err == nil && res == nil {
+			t.Errorf("%s: expected response got none", tc.desc)
+		}
+		if tc.err != nil && res != nil {
+			t.Errorf("%s: expected no response got %v", tc.desc, res)
+		}
+		checkStatus(t, tc.desc, err, tc.code, tc.reason)
+	}
+}
+
+func TestStreamErrorInterceptor(t *testing.T) {
+	for _, tc := range errorCases {
+		handler := func(interface{}, grpc.ServerStream) error {
+			return tc.err
+		}
+		err := StreamErrorInterceptor(nil, nil, &grpc.StreamServerInfo{}, handler)
+		checkStatus(t, tc.desc, err, tc.code, tc.reason)
+	}
+}
@@ -5,6 +5,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	agent "github.com/ultravioletrs/agent/agent"
@@ -30,15 +31,202 @@ func runEndpoint(svc agent.Service) endpoint.Endpoint {
 			DatasetProviders:   req.computation.DatasetProviders,
 			AlgorithmProviders: req.computation.AlgorithmProviders,
 			ResultConsumers:    req.computation.ResultConsumers,
+			ResultConsumerKeys: req.computation.ResultConsumerKeys,
+			LogReaders:         req.computation.LogReaders,
+			Runtime:            req.computation.Runtime,
+			Sandbox:            req.computation.Sandbox,
+			Limits:             req.computation.Limits,
 			Ttl:                req.computation.Ttl,
 			Metadata:           req.computation.Metadata,
 		}
 
-		cmpStr, err := svc.Run(ctx, cmp)
+		cmp, err := svc.Run(ctx, cmp)
 		if err != nil {
 			return runRes{}, err
 		}
 
-		return runRes{Computation: cmpStr}, nil
+		return runRes{ComputationID: cmp.ID, Computation: cmp}, nil
 	}
 }
+
+func algoEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(artifactReq)
+
+		if err := req.validate(); err != nil {
+			return algoRes{}, err
+		}
+
+		id, err := svc.Algo(ctx, agent.Artifact{Content: req.content, ID: req.id})
+		if err != nil {
+			return algoRes{}, err
+		}
+
+		return algoRes{AlgorithmID: id}, nil
+	}
+}
+
+func dataEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(artifactReq)
+
+		if err := req.validate(); err != nil {
+			return dataRes{}, err
+		}
+
+		id, err := svc.Data(ctx, agent.Artifact{Content: req.content, ID: req.id})
+		if err != nil {
+			return dataRes{}, err
+		}
+
+		return dataRes{DatasetID: id}, nil
+	}
+}
+
+func uploadEndpoint(upload func(context.Context, agent.Upload) (agent.UploadProgress, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(uploadReq)
+
+		if err := req.validate(); err != nil {
+			return uploadRes{}, err
+		}
+
+		progress, err := upload(ctx, agent.Upload{
+			ID:      req.id,
+			Size:    req.size,
+			Offset:  req.offset,
+			Content: req.content,
+		})
+		if err != nil {
+			return uploadRes{}, err
+		}
+
+		return progressRes(progress), nil
+	}
+}
+
+func uploadProgressEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(uploadProgressReq)
+
+		if err := req.validate(); err != nil {
+			return uploadRes{}, err
+		}
+
+		progress, err := svc.UploadProgress(ctx, req.id)
+		if err != nil {
+			return uploadRes{}, err
+		}
+
+		return progressRes(progress), nil
+	}
+}
+
+func provideKeyEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(provideKeyReq)
+
+		if err := req.validate(); err != nil {
+			return provideKeyRes{}, err
+		}
+
+		if err := svc.ProvideKey(ctx, req.id, req.key); err != nil {
+			return provideKeyRes{}, err
+		}
+
+		return provideKeyRes{}, nil
+	}
+}
+
+func resultEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emptyReq)
+
+		if err := req.validate(); err != nil {
+			return fileRes{}, err
+		}
+
+		result, err := svc.Result(ctx)
+		if err != nil {
+			return fileRes{}, err
+		}
+
+		return fileRes{content: result, contentType: octetStreamContentType, filename: "result.bin"}, nil
+	}
+}
+
+func provenanceEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emptyReq)
+
+		if err := req.validate(); err != nil {
+			return fileRes{}, err
+		}
+
+		doc, err := svc.Provenance(ctx)
+		if err != nil {
+			return fileRes{}, err
+		}
+
+		return fileRes{content: doc, contentType: contentType, filename: "provenance.json"}, nil
+	}
+}
+
+func attestationEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(attestationReq)
+
+		if err := req.validate(); err != nil {
+			return fileRes{}, err
+		}
+
+		report, err := svc.Attestation(ctx, req.nonce)
+		if err != nil {
+			return fileRes{}, err
+		}
+
+		return fileRes{content: report, contentType: octetStreamContentType, filename: "attestation.bin"}, nil
+	}
+}
+
+func statusEndpoint(svc agent.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(emptyReq)
+
+		if err := req.validate(); err != nil {
+			return statusRes{}, err
+		}
+
+		status, err := svc.Status(ctx)
+		if err != nil {
+			return statusRes{}, err
+		}
+
+		return statusRes{
+			State:     status.State.String(),
+			StartTime: optionalTime(status.StartTime),
+			EndTime:   optionalTime(status.EndTime),
+			ExitCode:  status.ExitCode,
+			Error:     status.Error,
+			WipeTime:  optionalTime(status.WipeTime),
+		}, nil
+	}
+}
+
+func progressRes(progress agent.UploadProgress) uploadRes {
+	return uploadRes{
+		ID:       progress.ID,
+		Size:     progress.Size,
+		Received: progress.Received,
+		Complete: progress.Complete,
+	}
+}
+
+// optionalTime returns nil for the zero time, so that unset times are
+// omitted from responses.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
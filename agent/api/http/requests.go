@@ -3,7 +3,13 @@
 
 package http
 
-import agent "github.com/ultravioletrs/agent/agent"
+import (
+	"fmt"
+	"io"
+
+	agent "github.com/ultravioletrs/agent/agent"
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
 
 type runReq struct {
 	computation agent.Computation
@@ -12,3 +18,79 @@ type runReq struct {
 func (req runReq) validate() error {
 	return nil
 }
+
+type artifactReq struct {
+	content []byte
+	id      string
+}
+
+func (req artifactReq) validate() error {
+	if len(req.content) == 0 {
+		return fmt.Errorf("%w: artifact content is required", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type uploadReq struct {
+	id      string
+	size    int64
+	offset  int64
+	content io.Reader
+}
+
+func (req uploadReq) validate() error {
+	if req.id == "" {
+		return fmt.Errorf("%w: artifact ID is required", agent.ErrMalformedEntity)
+	}
+	if req.size <= 0 {
+		return fmt.Errorf("%w: upload size must be positive", agent.ErrMalformedEntity)
+	}
+	if req.offset < 0 || req.offset > req.size {
+		return fmt.Errorf("%w: upload offset must be within the upload size", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type uploadProgressReq struct {
+	id string
+}
+
+func (req uploadProgressReq) validate() error {
+	if req.id == "" {
+		return fmt.Errorf("%w: artifact ID is required", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type provideKeyReq struct {
+	id  string
+	key []byte
+}
+
+func (req provideKeyReq) validate() error {
+	if req.id == "" {
+		return fmt.Errorf("%w: artifact ID is required", agent.ErrMalformedEntity)
+	}
+	if len(req.key) == 0 {
+		return fmt.Errorf("%w: key is required", agent.ErrMalformedEntity)
+	}
+	return nil
+}
+
+type attestationReq struct {
+	nonce []byte
+}
+
+func (req attestationReq) validate() error {
+	if len(req.nonce) != attestation.NonceSize {
+		return fmt.Errorf("%w: nonce must be %d bytes long", agent.ErrMalformedEntity, attestation.NonceSize)
+	}
+	return nil
+}
+
+// emptyReq is the request of the endpoints without parameters.
+type emptyReq struct{}
+
+func (req emptyReq) validate() error {
+	return nil
+}
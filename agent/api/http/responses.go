@@ -5,14 +5,24 @@ package http
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
+	agent "github.com/ultravioletrs/agent/agent"
 )
 
-var _ mainflux.Response = (*runRes)(nil)
+var (
+	_ mainflux.Response = (*runRes)(nil)
+	_ mainflux.Response = (*algoRes)(nil)
+	_ mainflux.Response = (*dataRes)(nil)
+	_ mainflux.Response = (*uploadRes)(nil)
+	_ mainflux.Response = (*provideKeyRes)(nil)
+	_ mainflux.Response = (*statusRes)(nil)
+)
 
 type runRes struct {
-	Computation string `json:"computation"`
+	ComputationID string            `json:"computation_id"`
+	Computation   agent.Computation `json:"computation"`
 }
 
 func (res runRes) Code() int {
@@ -26,3 +36,121 @@ func (res runRes) Headers() map[string]string {
 func (res runRes) Empty() bool {
 	return false
 }
+
+type algoRes struct {
+	AlgorithmID string `json:"algorithm_id"`
+}
+
+func (res algoRes) Code() int {
+	return http.StatusOK
+}
+
+func (res algoRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res algoRes) Empty() bool {
+	return false
+}
+
+type dataRes struct {
+	DatasetID string `json:"dataset_id"`
+}
+
+func (res dataRes) Code() int {
+	return http.StatusOK
+}
+
+func (res dataRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res dataRes) Empty() bool {
+	return false
+}
+
+type uploadRes struct {
+	ID       string `json:"id"`
+	Size     int64  `json:"size"`
+	Received int64  `json:"received"`
+	Complete bool   `json:"complete"`
+}
+
+func (res uploadRes) Code() int {
+	return http.StatusOK
+}
+
+func (res uploadRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res uploadRes) Empty() bool {
+	return false
+}
+
+type provideKeyRes struct{}
+
+func (res provideKeyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res provideKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res provideKeyRes) Empty() bool {
+	return true
+}
+
+type statusRes struct {
+	State     string     `json:"state"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	ExitCode  int        `json:"exit_code"`
+	Error     string     `json:"error,omitempty"`
+	WipeTime  *time.Time `json:"wipe_time,omitempty"`
+}
+
+func (res statusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res statusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res statusRes) Empty() bool {
+	return false
+}
+
+// fileRes is a binary response, which is downloaded as the named file.
+type fileRes struct {
+	content     []byte
+	contentType string
+	filename    string
+}
+
+// logEntryRes is a line of the newline-delimited JSON stream of the logs.
+type logEntryRes struct {
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+	Data   []byte    `json:"data"`
+}
+
+// eventRes is a line of the newline-delimited JSON stream of the events.
+type eventRes struct {
+	Type          string    `json:"type"`
+	ComputationID string    `json:"computation_id"`
+	Time          time.Time `json:"time"`
+	ArtifactID    string    `json:"artifact_id,omitempty"`
+	Step          int       `json:"step,omitempty"`
+	Steps         int       `json:"steps,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// errorRes describes a failed request. Kind is the kind of the error, as
+// reported in the error details of gRPC statuses.
+type errorRes struct {
+	Error string `json:"error"`
+	Kind  string `json:"kind"`
+}
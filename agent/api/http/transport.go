@@ -5,10 +5,16 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -19,27 +25,128 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-const contentType = "application/json"
+const (
+	contentType            = "application/json"
+	octetStreamContentType = "application/octet-stream"
+	multipartContentType   = "multipart/form-data"
+	ndjsonContentType      = "application/x-ndjson"
+
+	// filePart and idPart are the parts of a multipart upload holding the
+	// artifact and the declared ID of an encrypted artifact.
+	filePart = "file"
+	idPart   = "id"
+	// maxIDSize bounds the ID read from a multipart upload.
+	maxIDSize = 1 << 10
+
+	// DefaultMaxBodySize is the maximum size in bytes of the body of the
+	// requests read into memory, i.e. all but chunked uploads, which are
+	// staged on disk.
+	DefaultMaxBodySize = 4 << 20
+)
 
 var (
 	errUnsupportedContentType = errors.New("unsupported content type")
 	errInvalidQueryParams     = errors.New("invalid query params")
+	errMissingContentLength   = errors.New("missing content length")
+	errInvalidContentRange    = errors.New("invalid content range")
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc agent.Service, instanceID string) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. attestedTLS reports
+// whether the server serves the attested certificate of the agent,
+// tlsPublicKey is the DER encoded public key of the certificate it serves,
+// and maxBodySize bounds the body of the requests read into memory. When
+// zero, DefaultMaxBodySize is used.
+func MakeHandler(svc agent.Service, instanceID string, attestedTLS bool, tlsPublicKey []byte, maxBodySize int64) http.Handler {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	identify := identifier(attestedTLS, tlsPublicKey)
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(identify),
 	}
 
 	r := bone.New()
 
-	r.Post("/run", otelhttp.NewHandler(kithttp.NewServer(
+	r.Post("/run", otelhttp.NewHandler(limitBody(kithttp.NewServer(
 		runEndpoint(svc),
 		decodeRun,
 		encodeResponse,
 		opts...,
-	), "run"))
+	), maxBodySize), "run"))
+
+	r.Post("/algo", otelhttp.NewHandler(limitBody(kithttp.NewServer(
+		algoEndpoint(svc),
+		decodeArtifact,
+		encodeResponse,
+		opts...,
+	), maxBodySize), "algo"))
+
+	r.Post("/data", otelhttp.NewHandler(limitBody(kithttp.NewServer(
+		dataEndpoint(svc),
+		decodeArtifact,
+		encodeResponse,
+		opts...,
+	), maxBodySize), "data"))
+
+	r.Put("/algo/:id", otelhttp.NewHandler(kithttp.NewServer(
+		uploadEndpoint(svc.UploadAlgorithm),
+		decodeUpload,
+		encodeResponse,
+		opts...,
+	), "upload_algorithm"))
+
+	r.Put("/data/:id", otelhttp.NewHandler(kithttp.NewServer(
+		uploadEndpoint(svc.UploadDataset),
+		decodeUpload,
+		encodeResponse,
+		opts...,
+	), "upload_dataset"))
+
+	r.Get("/uploads/:id", otelhttp.NewHandler(kithttp.NewServer(
+		uploadProgressEndpoint(svc),
+		decodeUploadProgress,
+		encodeResponse,
+		opts...,
+	), "upload_progress"))
+
+	r.Post("/keys/:id", otelhttp.NewHandler(limitBody(kithttp.NewServer(
+		provideKeyEndpoint(svc),
+		decodeProvideKey,
+		encodeResponse,
+		opts...,
+	), maxBodySize), "provide_key"))
+
+	r.Get("/result", otelhttp.NewHandler(kithttp.NewServer(
+		resultEndpoint(svc),
+		decodeEmpty,
+		encodeFile,
+		opts...,
+	), "result"))
+
+	r.Get("/provenance", otelhttp.NewHandler(kithttp.NewServer(
+		provenanceEndpoint(svc),
+		decodeEmpty,
+		encodeFile,
+		opts...,
+	), "provenance"))
+
+	r.Get("/attestation", otelhttp.NewHandler(kithttp.NewServer(
+		attestationEndpoint(svc),
+		decodeAttestation,
+		encodeFile,
+		opts...,
+	), "attestation"))
+
+	r.Get("/status", otelhttp.NewHandler(kithttp.NewServer(
+		statusEndpoint(svc),
+		decodeEmpty,
+		encodeResponse,
+		opts...,
+	), "status"))
+
+	r.Get("/logs", otelhttp.NewHandler(logsHandler(svc, identify), "logs"))
+	r.Get("/events", otelhttp.NewHandler(eventsHandler(svc, identify), "events"))
 
 	r.GetFunc("/health", mainflux.Health("agent", instanceID))
 	r.Handle("/metrics", promhttp.Handler())
@@ -47,6 +154,33 @@ func MakeHandler(svc agent.Service, instanceID string) http.Handler {
 	return r
 }
 
+// limitBody bounds the body of the requests served by h to maxBodySize
+// bytes. Reading past it fails with *http.MaxBytesError.
+func limitBody(h http.Handler, maxBodySize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// identifier returns the request function adding the identities of the TLS
+// client, if it presented a certificate, and the public key of the server
+// certificate to the context. Requests received over TLS are marked as
+// attested when the server serves the attested certificate of the agent.
+func identifier(attestedTLS bool, tlsPublicKey []byte) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		if r.TLS == nil {
+			return ctx
+		}
+		if attestedTLS {
+			ctx = agent.WithAttestedTLS(ctx)
+		}
+		ctx = agent.WithTLSPublicKey(ctx, tlsPublicKey)
+
+		return agent.WithIdentities(ctx, agent.TLSIdentities(*r.TLS))
+	}
+}
+
 func decodeRun(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errUnsupportedContentType
@@ -60,6 +194,206 @@ func decodeRun(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// decodeArtifact reads an algorithm or dataset sent either as the request
+// body or as the file part of a multipart form. The declared ID of an
+// encrypted artifact is given by the id query parameter or form part.
+func decodeArtifact(_ context.Context, r *http.Request) (interface{}, error) {
+	req := artifactReq{id: r.URL.Query().Get(idPart)}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, errUnsupportedContentType
+	}
+
+	switch mediaType {
+	case octetStreamContentType:
+		if req.content, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+	case multipartContentType:
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", agent.ErrMalformedEntity, err)
+		}
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", agent.ErrMalformedEntity, err)
+			}
+			switch part.FormName() {
+			case filePart:
+				req.content, err = io.ReadAll(part)
+			case idPart:
+				var id []byte
+				id, err = io.ReadAll(io.LimitReader(part, maxIDSize))
+				req.id = string(id)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errUnsupportedContentType
+	}
+
+	return req, nil
+}
+
+// decodeUpload reads a chunk of a resumable upload. The Content-Range header
+// gives the offset of the chunk and the total size of the artifact; without
+// it, the body holds the whole artifact.
+func decodeUpload(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), octetStreamContentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	req := uploadReq{
+		id:      bone.GetValue(r, "id"),
+		size:    r.ContentLength,
+		content: r.Body,
+	}
+
+	if cr := r.Header.Get("Content-Range"); cr != "" {
+		var end int64
+		if _, err := fmt.Sscanf(cr, "bytes %d-%d/%d", &req.offset, &end, &req.size); err != nil {
+			return nil, errInvalidContentRange
+		}
+		if end < req.offset || end >= req.size {
+			return nil, errInvalidContentRange
+		}
+		req.content = io.LimitReader(r.Body, end-req.offset+1)
+		return req, nil
+	}
+	if req.size < 0 {
+		return nil, errMissingContentLength
+	}
+
+	return req, nil
+}
+
+func decodeUploadProgress(_ context.Context, r *http.Request) (interface{}, error) {
+	return uploadProgressReq{id: bone.GetValue(r, "id")}, nil
+}
+
+func decodeProvideKey(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), octetStreamContentType) {
+		return nil, errUnsupportedContentType
+	}
+
+	key, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return provideKeyReq{id: bone.GetValue(r, "id"), key: key}, nil
+}
+
+func decodeAttestation(_ context.Context, r *http.Request) (interface{}, error) {
+	nonce, err := hex.DecodeString(r.URL.Query().Get("nonce"))
+	if err != nil {
+		return nil, errInvalidQueryParams
+	}
+
+	return attestationReq{nonce: nonce}, nil
+}
+
+func decodeEmpty(_ context.Context, _ *http.Request) (interface{}, error) {
+	return emptyReq{}, nil
+}
+
+// logsHandler streams the logs as newline-delimited JSON. It is served
+// without go-kit, which does not support streaming responses.
+func logsHandler(svc agent.Service, identify kithttp.RequestFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := identify(r.Context(), r)
+
+		follow := false
+		if v := r.URL.Query().Get("follow"); v != "" {
+			var err error
+			if follow, err = strconv.ParseBool(v); err != nil {
+				encodeError(ctx, errInvalidQueryParams, w)
+				return
+			}
+		}
+
+		entries, err := svc.Logs(ctx, follow)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+		for entry := range entries {
+			if err := enc.Encode(logEntryRes{Stream: entry.Stream, Time: entry.Time, Data: entry.Data}); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// eventsHandler streams the events of the computation as newline-delimited
+// JSON.
+func eventsHandler(svc agent.Service, identify kithttp.RequestFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := identify(r.Context(), r)
+
+		events, err := svc.Subscribe(ctx)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+		for event := range events {
+			res := eventRes{
+				Type:          event.Type.String(),
+				ComputationID: event.ComputationID,
+				Time:          event.Time,
+				ArtifactID:    event.ArtifactID,
+				Step:          event.Step,
+				Steps:         event.Steps,
+				Error:         event.Error,
+			}
+			if err := enc.Encode(res); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// encodeFile writes a binary response as a file download, along with the
+// SHA-256 digest of its content.
+func encodeFile(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(fileRes)
+	digest := sha256.Sum256(res.content)
+
+	w.Header().Set("Content-Type", res.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": res.filename}))
+	w.Header().Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(digest[:])+":")
+	w.Header().Set("Content-Length", strconv.Itoa(len(res.content)))
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(res.content)
+	return err
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -81,27 +415,42 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	w.Header().Set("Content-Type", contentType)
 
-	switch err {
-	case agent.ErrMalformedEntity:
-		w.WriteHeader(http.StatusBadRequest)
-	case agent.ErrUnauthorizedAccess:
-		w.WriteHeader(http.StatusForbidden)
-	case errUnsupportedContentType:
+	var maxBytesErr *http.MaxBytesError
+	kind := errorKind(err)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	case errors.Is(err, errUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
-	case errInvalidQueryParams:
-		w.WriteHeader(http.StatusBadRequest)
-	case io.ErrUnexpectedEOF:
-		w.WriteHeader(http.StatusBadRequest)
-	case io.EOF:
-		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, errMissingContentLength):
+		w.WriteHeader(http.StatusLengthRequired)
 	default:
-		switch err.(type) {
-		case *json.SyntaxError:
-			w.WriteHeader(http.StatusBadRequest)
-		case *json.UnmarshalTypeError:
-			w.WriteHeader(http.StatusBadRequest)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		w.WriteHeader(kind.HTTPStatus())
+	}
+
+	_ = json.NewEncoder(w).Encode(errorRes{Error: err.Error(), Kind: kind.String()})
+}
+
+// errorKind returns the kind of err, treating the errors of decoding a
+// request as malformed entities.
+func errorKind(err error) agent.Kind {
+	var (
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		maxBytesErr *http.MaxBytesError
+	)
+	switch {
+	case errors.Is(err, errUnsupportedContentType),
+		errors.Is(err, errMissingContentLength),
+		errors.Is(err, errInvalidQueryParams),
+		errors.Is(err, errInvalidContentRange),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, io.EOF),
+		errors.As(err, &syntaxErr),
+		errors.As(err, &typeErr),
+		errors.As(err, &maxBytesErr):
+		return agent.KindMalformedEntity
+	default:
+		return agent.ErrorKind(err)
 	}
 }
@@ -0,0 +1,921 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ultravioletrs/agent/agent"
+)
+
+const (
+	testID          = "4a4f1bd7b0b5e2c6a0d9f3f2a1c5e7d8b9a0c1d2e3f4a5b6c7d8e9f0a1b2c3d4"
+	testMaxBodySize = 1 << 10
+)
+
+var testTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// testService records the last request reaching the service, and fails
+// every request with err when it is set.
+type testService struct {
+	agent.Service
+	err    error
+	req    interface{}
+	logs   []agent.LogEntry
+	events []agent.Event
+}
+
+// uploadCall, keyCall and logsCall record the requests of the service
+// whose arguments are not recorded as they are.
+type (
+	uploadCall struct {
+		id      string
+		size    int64
+		offset  int64
+		content string
+	}
+	keyCall struct {
+		id  string
+		key string
+	}
+	logsCall struct {
+		follow bool
+	}
+)
+
+func (ts *testService) Run(_ context.Context, cmp agent.Computation) (agent.Computation, error) {
+	ts.req = cmp
+	return cmp, ts.err
+}
+
+func (ts *testService) Algo(_ context.Context, algorithm agent.Artifact) (string, error) {
+	ts.req = algorithm
+	return testID, ts.err
+}
+
+func (ts *testService) Data(_ context.Context, dataset agent.Artifact) (string, error) {
+	ts.req = dataset
+	return testID, ts.err
+}
+
+func (ts *testService) UploadAlgorithm(_ context.Context, up agent.Upload) (agent.UploadProgress, error) {
+	return ts.upload(up)
+}
+
+func (ts *testService) UploadDataset(_ context.Context, up agent.Upload) (agent.UploadProgress, error) {
+	return ts.upload(up)
+}
+
+func (ts *testService) upload(up agent.Upload) (agent.UploadProgress, error) {
+	content, err := io.ReadAll(up.Content)
+	if err != nil {
+		return agent.UploadProgress{}, err
+	}
+	ts.req = uploadCall{id: up.ID, size: up.Size, offset: up.Offset, content: string(content)}
+	received := up.Offset + int64(len(content))
+
+	return agent.UploadProgress{ID: up.ID, Size: up.Size, Received: received, Complete: received == up.Size}, ts.err
+}
+
+func (ts *testService) UploadProgress(_ context.Context, id string) (agent.UploadProgress, error) {
+	ts.req = id
+	return agent.UploadProgress{ID: id, Size: 10, Received: 4}, ts.err
+}
+
+func (ts *testService) ProvideKey(_ context.Context, id string, key []byte) error {
+	ts.req = keyCall{id: id, key: string(key)}
+	return ts.err
+}
+
+func (ts *testService) Result(_ context.Context) ([]byte, error) {
+	return []byte("result"), ts.err
+}
+
+func (ts *testService) Attestation(_ context.Context, nonce []byte) ([]byte, error) {
+	ts.req = nonce
+	return []byte("report"), ts.err
+}
+
+func (ts *testService) Status(_ context.Context) (agent.RunStatus, error) {
+	return agent.RunStatus{State: agent.Running, StartTime: testTime}, ts.err
+}
+
+func (ts *testService) Logs(_ context.Context, follow bool) (<-chan agent.LogEntry, error) {
+	ts.req = logsCall{follow: follow}
+	if ts.err != nil {
+		return nil, ts.err
+	}
+	entries := make(chan agent.LogEntry, len(ts.logs))
+	for _, entry := range ts.logs {
+		entries <- entry
+	}
+	close(entries)
+
+	return entries, nil
+}
+
+func (ts *testService) Subscribe(_ context.Context) (<-chan agent.Event, error) {
+	if ts.err != nil {
+		return nil, ts.err
+	}
+	events := make(chan agent.Event, len(ts.events))
+	for _, event := range ts.events {
+		events <- event
+	}
+	close(events)
+
+	return events, nil
+}
+
+// testRequest describes a request to the handler.
+type testRequest struct {
+	method      string
+	path        string
+	contentType string
+	header      map[string]string
+	body        string
+}
+
+// serve sends req to the handler of svc and returns the response.
+func serve(svc agent.Service, req testRequest) *http.Response {
+	r := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+	if req.contentType != "" {
+		r.Header.Set("Content-Type", req.contentType)
+	}
+	for k, v := range req.header {
+		r.Header.Set(k, v)
+	}
+	if req.header["Content-Length"] == "-1" {
+		r.ContentLength = -1
+	}
+	w := httptest.NewRecorder()
+	MakeHandler(svc, "", false, nil, testMaxBodySize).ServeHTTP(w, r)
+
+	return w.Result()
+}
+
+// multipartBody returns a multipart form with the given parts and its
+// content type.
+func multipartBody(t *testing.T, parts map[string]string) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for name, content := range parts {
+		if err := mw.WriteField(name, content); err != nil {
+			t.Fatalf("unexpected error writing form: %s", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("unexpected error writing form: %s", err)
+	}
+
+	return buf.String(), mw.FormDataContentType()
+}
+
+func TestDecodeRequests(t *testing.T) {
+	form, formContentType := multipartBody(t, map[string]string{filePart: "algorithm", idPart: testID})
+	emptyForm, emptyFormContentType := multipartBody(t, map[string]string{idPart: testID})
+	large := strings.Repeat("a", testMaxBodySize+1)
+	nonce := bytes.Repeat([]byte{1}, 32)
+
+	cases := []struct {
+		desc   string
+		req    testRequest
+		status int
+		call   interface{}
+		res    map[string]interface{}
+	}{
+		{
+			desc:   "run computation",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: contentType, body: `{"id":"cmp","algorithms":["a"],"ttl":5}`},
+			status: http.StatusOK,
+			call:   agent.Computation{ID: "cmp", Algorithms: []string{"a"}, Ttl: 5},
+			res:    map[string]interface{}{"computation_id": "cmp"},
+		},
+		{
+			desc:   "run computation with malformed manifest",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: contentType, body: `{"id":`},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "run computation with mistyped manifest",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: contentType, body: `{"ttl":"5"}`},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "run computation without JSON content type",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: octetStreamContentType, body: `{}`},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "run computation with too large manifest",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: contentType, body: `{"id":"` + large + `"}`},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "upload algorithm as octet stream",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: "algorithm"},
+			status: http.StatusOK,
+			call:   agent.Artifact{Content: []byte("algorithm")},
+			res:    map[string]interface{}{"algorithm_id": testID},
+		},
+		{
+			desc:   "upload encrypted algorithm with ID query parameter",
+			req:    testRequest{method: http.MethodPost, path: "/algo?id=" + testID, contentType: octetStreamContentType, body: "algorithm"},
+			status: http.StatusOK,
+			call:   agent.Artifact{Content: []byte("algorithm"), ID: testID},
+			res:    map[string]interface{}{"algorithm_id": testID},
+		},
+		{
+			desc:   "upload encrypted algorithm as multipart form",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: formContentType, body: form},
+			status: http.StatusOK,
+			call:   agent.Artifact{Content: []byte("algorithm"), ID: testID},
+			res:    map[string]interface{}{"algorithm_id": testID},
+		},
+		{
+			desc:   "upload algorithm as multipart form without file",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: emptyFormContentType, body: emptyForm},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "upload algorithm as malformed multipart form",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: formContentType, body: "algorithm"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "upload empty algorithm",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "upload algorithm with unsupported content type",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: "text/plain", body: "algorithm"},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "upload too large algorithm",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: large},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc: "upload too large algorithm as multipart form",
+			req: func() testRequest {
+				body, ct := multipartBody(t, map[string]string{filePart: large})
+				return testRequest{method: http.MethodPost, path: "/algo", contentType: ct, body: body}
+			}(),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "upload dataset as octet stream",
+			req:    testRequest{method: http.MethodPost, path: "/data", contentType: octetStreamContentType, body: "dataset"},
+			status: http.StatusOK,
+			call:   agent.Artifact{Content: []byte("dataset")},
+			res:    map[string]interface{}{"dataset_id": testID},
+		},
+		{
+			desc:   "upload chunk of algorithm",
+			req:    testRequest{method: http.MethodPut, path: "/algo/" + testID, contentType: octetStreamContentType, header: map[string]string{"Content-Range": "bytes 4-7/10"}, body: "chunk"},
+			status: http.StatusOK,
+			call:   uploadCall{id: testID, size: 10, offset: 4, content: "chun"},
+			res:    map[string]interface{}{"id": testID, "size": float64(10), "received": float64(8), "complete": false},
+		},
+		{
+			desc:   "upload whole dataset without content range",
+			req:    testRequest{method: http.MethodPut, path: "/data/" + testID, contentType: octetStreamContentType, body: large},
+			status: http.StatusOK,
+			call:   uploadCall{id: testID, size: int64(len(large)), content: large},
+			res:    map[string]interface{}{"id": testID, "received": float64(len(large)), "complete": true},
+		},
+		{
+			desc:   "upload chunk with invalid content range",
+			req:    testRequest{method: http.MethodPut, path: "/algo/" + testID, contentType: octetStreamContentType, header: map[string]string{"Content-Range": "bytes 7-4/10"}, body: "chunk"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "upload chunk beyond the artifact size",
+			req:    testRequest{method: http.MethodPut, path: "/algo/" + testID, contentType: octetStreamContentType, header: map[string]string{"Content-Range": "bytes 4-10/10"}, body: "chunk"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "upload without content length and content range",
+			req:    testRequest{method: http.MethodPut, path: "/algo/" + testID, contentType: octetStreamContentType, header: map[string]string{"Content-Length": "-1"}, body: "chunk"},
+			status: http.StatusLengthRequired,
+		},
+		{
+			desc:   "upload chunk with unsupported content type",
+			req:    testRequest{method: http.MethodPut, path: "/algo/" + testID, contentType: contentType, body: "chunk"},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "report upload progress",
+			req:    testRequest{method: http.MethodGet, path: "/uploads/" + testID},
+			status: http.StatusOK,
+			call:   testID,
+			res:    map[string]interface{}{"id": testID, "size": float64(10), "received": float64(4), "complete": false},
+		},
+		{
+			desc:   "provide key",
+			req:    testRequest{method: http.MethodPost, path: "/keys/" + testID, contentType: octetStreamContentType, body: "key"},
+			status: http.StatusNoContent,
+			call:   keyCall{id: testID, key: "key"},
+		},
+		{
+			desc:   "provide key with unsupported content type",
+			req:    testRequest{method: http.MethodPost, path: "/keys/" + testID, contentType: contentType, body: "key"},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "provide too large key",
+			req:    testRequest{method: http.MethodPost, path: "/keys/" + testID, contentType: octetStreamContentType, body: large},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "request attestation",
+			req:    testRequest{method: http.MethodGet, path: "/attestation?nonce=" + hex.EncodeToString(nonce)},
+			status: http.StatusOK,
+			call:   nonce,
+		},
+		{
+			desc:   "request attestation with malformed nonce",
+			req:    testRequest{method: http.MethodGet, path: "/attestation?nonce=zz"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "report status",
+			req:    testRequest{method: http.MethodGet, path: "/status"},
+			status: http.StatusOK,
+			res:    map[string]interface{}{"state": agent.Running.String(), "start_time": testTime.Format(time.RFC3339), "exit_code": float64(0)},
+		},
+	}
+
+	for _, tc := range cases {
+		svc := &testService{}
+		res := serve(svc, tc.req)
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("%s: unexpected error reading response: %s", tc.desc, err)
+		}
+		if res.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d got %d: %s", tc.desc, tc.status, res.StatusCode, body)
+			continue
+		}
+		if !reflect.DeepEqual(svc.req, tc.call) {
+			t.Errorf("%s: expected service request %#v got %#v", tc.desc, tc.call, svc.req)
+		}
+		if tc.res == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			t.Errorf("%s: unexpected error decoding response %s: %s", tc.desc, body, err)
+			continue
+		}
+		for k, v := range tc.res {
+			if !reflect.DeepEqual(fields[k], v) {
+				t.Errorf("%s: expected %s %v got %v", tc.desc, k, v, fields[k])
+			}
+		}
+	}
+}
+
+func TestFileResponses(t *testing.T) {
+	cases := []struct {
+		desc        string
+		path        string
+		content     string
+		contentType string
+		filename    string
+	}{
+		{
+			desc:        "download result",
+			path:        "/result",
+			content:     "result",
+			contentType: octetStreamContentType,
+			filename:    "result.bin",
+		},
+		{
+			desc:        "download attestation",
+			path:        "/attestation?nonce=" + strings.Repeat("00", 32),
+			content:     "report",
+			contentType: octetStreamContentType,
+			filename:    "attestation.bin",
+		},
+	}
+
+	for _, tc := range cases {
+		res := serve(&testService{}, testRequest{method: http.MethodGet, path: tc.path})
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatalf("%s: unexpected error reading response: %s", tc.desc, err)
+		}
+		if res.StatusCode != http.StatusOK || string(body) != tc.content {
+			t.Errorf("%s: expected status %d and content %q got %d and %q", tc.desc, http.StatusOK, tc.content, res.StatusCode, body)
+		}
+		if ct := res.Header.Get("Content-Type"); ct != tc.contentType {
+			t.Errorf("%s: expected content type %s got %s", tc.desc, tc.contentType, ct)
+		}
+		if cd := res.Header.Get("Content-Disposition"); !strings.Contains(cd, tc.filename) {
+			t.Errorf("%s: expected content disposition naming %s got %s", tc.desc, tc.filename, cd)
+		}
+		sum := sha256.Sum256([]byte(tc.content))
+		if digest := res.Header.Get("Content-Digest"); !strings.Contains(digest, ":"+base64.StdEncoding.EncodeToString(sum[:])+":") {
+			t.Errorf("%s: expected content digest of the content got %s", tc.desc, digest)
+		}
+	}
+}
+
+func TestErrorResponses(t *testing.T) {
+	cases := []struct {
+		desc   string
+		req    testRequest
+		err    error
+		status int
+		kind   agent.Kind
+	}{
+		{
+			desc:   "run computation with invalid manifest",
+			req:    testRequest{method: http.MethodPost, path: "/run", contentType: contentType, body: `{}`},
+			err:    fmt.Errorf("%w: missing ID", agent.ErrMalformedEntity),
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "upload algorithm as undeclared party",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: "algorithm"},
+			err:    agent.ErrUnauthorizedAccess,
+			status: http.StatusForbidden,
+			kind:   agent.KindUnauthorized,
+		},
+		{
+			desc:   "upload undeclared dataset",
+			req:    testRequest{method: http.MethodPost, path: "/data", contentType: octetStreamContentType, body: "dataset"},
+			err:    fmt.Errorf("%w: %s", agent.ErrUndeclaredDataset, testID),
+			status: http.StatusNotFound,
+			kind:   agent.KindNotFound,
+		},
+		{
+			desc:   "upload chunk at wrong offset",
+			req:    testRequest{method: http.MethodPut, path: "/data/" + testID, contentType: octetStreamContentType, body: "dataset"},
+			err:    agent.ErrUploadOffset,
+			status: http.StatusConflict,
+			kind:   agent.KindWrongState,
+		},
+		{
+			desc:   "download result of timed out computation",
+			req:    testRequest{method: http.MethodGet, path: "/result"},
+			err:    fmt.Errorf("%w: %w", agent.ErrComputationFailed, agent.ErrTimeout),
+			status: http.StatusUnprocessableEntity,
+			kind:   agent.KindLimitExceeded,
+		},
+		{
+			desc:   "download result of failed computation",
+			req:    testRequest{method: http.MethodGet, path: "/result"},
+			err:    agent.ErrComputationFailed,
+			status: http.StatusUnprocessableEntity,
+			kind:   agent.KindRuntimeFailure,
+		},
+		{
+			desc:   "report status with unexpected error",
+			req:    testRequest{method: http.MethodGet, path: "/status"},
+			err:    errors.New("unexpected"),
+			status: http.StatusInternalServerError,
+			kind:   agent.KindInternal,
+		},
+		{
+			desc:   "stream logs as undeclared party",
+			req:    testRequest{method: http.MethodGet, path: "/logs"},
+			err:    agent.ErrUnauthorizedAccess,
+			status: http.StatusForbidden,
+			kind:   agent.KindUnauthorized,
+		},
+		{
+			desc:   "stream logs with malformed follow parameter",
+			req:    testRequest{method: http.MethodGet, path: "/logs?follow=maybe"},
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "stream events of wiped computation",
+			req:    testRequest{method: http.MethodGet, path: "/events"},
+			err:    agent.ErrWiped,
+			status: http.StatusConflict,
+			kind:   agent.KindWrongState,
+		},
+		{
+			desc:   "upload too large algorithm",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: strings.Repeat("a", testMaxBodySize+1)},
+			status: http.StatusRequestEntityTooLarge,
+			kind:   agent.KindMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res := serve(&testService{err: tc.err}, tc.req)
+		if res.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d got %d", tc.desc, tc.status, res.StatusCode)
+		}
+		if ct := res.Header.Get("Content-Type"); ct != contentType {
+			t.Errorf("%s: expected content type %s got %s", tc.desc, contentType, ct)
+		}
+		var er errorRes
+		if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+			t.Errorf("%s: unexpected error decoding response: %s", tc.desc, err)
+			continue
+		}
+		if er.Kind != tc.kind.String() || er.Error == "" {
+			t.Errorf("%s: expected error of kind %s got %+v", tc.desc, tc.kind, er)
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	syntaxErr := json.Unmarshal([]byte("{"), &struct{}{})
+
+	cases := []struct {
+		desc   string
+		err    error
+		status int
+		kind   agent.Kind
+	}{
+		{
+			desc:   "wrapped malformed entity",
+			err:    fmt.Errorf("%w: missing ID", agent.ErrMalformedEntity),
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "wrapped undeclared algorithm",
+			err:    fmt.Errorf("%w: %s", agent.ErrUndeclaredAlgorithm, testID),
+			status: http.StatusNotFound,
+			kind:   agent.KindNotFound,
+		},
+		{
+			desc:   "already uploaded artifact",
+			err:    agent.ErrAlreadyUploaded,
+			status: http.StatusConflict,
+			kind:   agent.KindWrongState,
+		},
+		{
+			desc:   "failed computation wrapping its memory limit",
+			err:    fmt.Errorf("%w: %w", agent.ErrComputationFailed, agent.ErrMemoryLimit),
+			status: http.StatusUnprocessableEntity,
+			kind:   agent.KindLimitExceeded,
+		},
+		{
+			desc:   "malformed JSON",
+			err:    syntaxErr,
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "truncated body",
+			err:    io.ErrUnexpectedEOF,
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "invalid content range",
+			err:    errInvalidContentRange,
+			status: http.StatusBadRequest,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "unsupported content type",
+			err:    errUnsupportedContentType,
+			status: http.StatusUnsupportedMediaType,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "missing content length",
+			err:    errMissingContentLength,
+			status: http.StatusLengthRequired,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "too large multipart body",
+			err:    fmt.Errorf("%w: %w", agent.ErrMalformedEntity, &http.MaxBytesError{Limit: testMaxBodySize}),
+			status: http.StatusRequestEntityTooLarge,
+			kind:   agent.KindMalformedEntity,
+		},
+		{
+			desc:   "canceled context",
+			err:    context.Canceled,
+			status: http.StatusInternalServerError,
+			kind:   agent.KindInternal,
+		},
+		{
+			desc:   "wrapped exceeded context deadline",
+			err:    fmt.Errorf("reading upload: %w", context.DeadlineExceeded),
+			status: http.StatusInternalServerError,
+			kind:   agent.KindInternal,
+		},
+		{
+			desc:   "unexpected error",
+			err:    errors.New("unexpected"),
+			status: http.StatusInternalServerError,
+			kind:   agent.KindInternal,
+		},
+	}
+
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		encodeError(context.Background(), tc.err, w)
+		res := w.Result()
+		if res.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d got %d", tc.desc, tc.status, res.StatusCode)
+		}
+		var er errorRes
+		if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
+			t.Errorf("%s: unexpected error decoding response: %s", tc.desc, err)
+			continue
+		}
+		if want := (errorRes{Error: tc.err.Error(), Kind: tc.kind.String()}); er != want {
+			t.Errorf("%s: expected response %+v got %+v", tc.desc, want, er)
+		}
+	}
+}
+
+// readLines decodes the newline-delimited JSON lines of body into values
+// returned by newValue.
+func readLines(t *testing.T, body io.Reader, newValue func() interface{}) []interface{} {
+	t.Helper()
+
+	var values []interface{}
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		v := newValue()
+		if err := json.Unmarshal(scanner.Bytes(), v); err != nil {
+			t.Fatalf("unexpected error decoding line %s: %s", scanner.Bytes(), err)
+		}
+		values = append(values, v)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("unexpected error reading lines: %s", err)
+	}
+
+	return values
+}
+
+func TestLogsStream(t *testing.T) {
+	entries := []agent.LogEntry{
+		{Stream: "stdout", Time: testTime, Data: []byte("out\n")},
+		{Stream: "stderr", Time: testTime.Add(time.Second), Data: []byte("err\n")},
+	}
+
+	cases := []struct {
+		desc   string
+		path   string
+		follow bool
+	}{
+		{
+			desc: "stream logs",
+			path: "/logs",
+		},
+		{
+			desc:   "follow logs",
+			path:   "/logs?follow=true",
+			follow: true,
+		},
+	}
+
+	for _, tc := range cases {
+		svc := &testService{logs: entries}
+		res := serve(svc, testRequest{method: http.MethodGet, path: tc.path})
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("%s: expected status %d got %d", tc.desc, http.StatusOK, res.StatusCode)
+			continue
+		}
+		if ct := res.Header.Get("Content-Type"); ct != ndjsonContentType {
+			t.Errorf("%s: expected content type %s got %s", tc.desc, ndjsonContentType, ct)
+		}
+		if call := (logsCall{follow: tc.follow}); svc.req != call {
+			t.Errorf("%s: expected service request %+v got %+v", tc.desc, call, svc.req)
+		}
+		lines := readLines(t, res.Body, func() interface{} { return &logEntryRes{} })
+		if len(lines) != len(entries) {
+			t.Fatalf("%s: expected %d lines got %d", tc.desc, len(entries), len(lines))
+		}
+		for i, line := range lines {
+			entry := entries[i]
+			want := &logEntryRes{Stream: entry.Stream, Time: entry.Time, Data: entry.Data}
+			if !reflect.DeepEqual(line, want) {
+				t.Errorf("%s: expected line %+v got %+v", tc.desc, want, line)
+			}
+		}
+	}
+}
+
+func TestEventsStream(t *testing.T) {
+	events := []agent.Event{
+		{Type: agent.EventAlgorithmUploaded, ComputationID: "cmp", Time: testTime, ArtifactID: testID},
+		{Type: agent.EventProgress, ComputationID: "cmp", Time: testTime, Step: 1, Steps: 2},
+		{Type: agent.EventRunFailed, ComputationID: "cmp", Time: testTime, Error: "failed"},
+	}
+	want := []string{
+		`{"type":"ALGORITHM_UPLOADED","computation_id":"cmp","time":"2024-01-02T03:04:05Z","artifact_id":"` + testID + `"}`,
+		`{"type":"PROGRESS","computation_id":"cmp","time":"2024-01-02T03:04:05Z","step":1,"steps":2}`,
+		`{"type":"RUN_FAILED","computation_id":"cmp","time":"2024-01-02T03:04:05Z","error":"failed"}`,
+	}
+
+	res := serve(&testService{events: events}, testRequest{method: http.MethodGet, path: "/events"})
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d got %d", http.StatusOK, res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != ndjsonContentType {
+		t.Errorf("expected content type %s got %s", ndjsonContentType, ct)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("unexpected error reading response: %s", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("expected lines %v got %v", want, lines)
+	}
+}
+
+// newTestCertificate returns a certificate with the common name cn, signed
+// by parent or self-signed when parent is nil.
+func newTestCertificate(t *testing.T, cn string, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("unexpected error generating serial number: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	issuer, signer := template, interface{}(key)
+	if parent != nil {
+		issuer, signer = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error parsing certificate: %s", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestTLSIdentity(t *testing.T) {
+	algorithm := []byte("algorithm")
+	sum := sha256.Sum256(algorithm)
+	algorithmID := hex.EncodeToString(sum[:])
+	key := bytes.Repeat([]byte{1}, 32)
+
+	ca := newTestCertificate(t, "ca", nil)
+	provider := newTestCertificate(t, "provider", nil)
+	alice := newTestCertificate(t, "alice", &ca)
+	mallory := newTestCertificate(t, "mallory", nil)
+	fingerprint := agent.CertificateIdentities(provider.Leaf, false)[0]
+
+	cases := []struct {
+		desc        string
+		cert        *tls.Certificate
+		clientCAs   bool
+		attestedTLS bool
+		req         testRequest
+		status      int
+	}{
+		{
+			desc:   "upload algorithm with the fingerprint of a declared provider",
+			cert:   &provider,
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: string(algorithm)},
+			status: http.StatusOK,
+		},
+		{
+			desc:      "upload algorithm with the verified common name of a declared provider",
+			cert:      &alice,
+			clientCAs: true,
+			req:       testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: string(algorithm)},
+			status:    http.StatusOK,
+		},
+		{
+			desc:   "upload algorithm with the unverified common name of a declared provider",
+			cert:   &alice,
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: string(algorithm)},
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "upload algorithm as undeclared party",
+			cert:   &mallory,
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: string(algorithm)},
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "upload algorithm without client certificate",
+			req:    testRequest{method: http.MethodPost, path: "/algo", contentType: octetStreamContentType, body: string(algorithm)},
+			status: http.StatusForbidden,
+		},
+		{
+			desc:        "provide key over attested TLS",
+			cert:        &provider,
+			attestedTLS: true,
+			req:         testRequest{method: http.MethodPost, path: "/keys/" + algorithmID, contentType: octetStreamContentType, body: string(key)},
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:   "provide key over TLS without attested certificate",
+			cert:   &provider,
+			req:    testRequest{method: http.MethodPost, path: "/keys/" + algorithmID, contentType: octetStreamContentType, body: string(key)},
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, err := agent.New(agent.Config{
+			WorkDir:   t.TempDir(),
+			UploadDir: t.TempDir(),
+			Manifest: &agent.Computation{
+				ID:                 "computation",
+				Algorithms:         []string{algorithmID},
+				Datasets:           []string{strings.Repeat("d", 64)},
+				AlgorithmProviders: []string{fingerprint, "alice"},
+				DatasetProviders:   []string{fingerprint},
+				ResultConsumers:    []string{fingerprint},
+				Runtime:            agent.RuntimeWasm,
+			},
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error creating service: %s", tc.desc, err)
+		}
+
+		srv := httptest.NewUnstartedServer(MakeHandler(svc, "", tc.attestedTLS, nil, testMaxBodySize))
+		// As configured by the server, the common name of client
+		// certificates is only trusted when they are verified against the
+		// client CAs.
+		srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+		if tc.clientCAs {
+			srv.TLS.ClientCAs = x509.NewCertPool()
+			srv.TLS.ClientCAs.AddCert(ca.Leaf)
+			srv.TLS.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+		srv.StartTLS()
+
+		transport := srv.Client().Transport.(*http.Transport).Clone()
+		if tc.cert != nil {
+			transport.TLSClientConfig.Certificates = []tls.Certificate{*tc.cert}
+		}
+		client := &http.Client{Transport: transport}
+
+		req, err := http.NewRequest(tc.req.method, srv.URL+tc.req.path, strings.NewReader(tc.req.body))
+		if err != nil {
+			t.Fatalf("%s: unexpected error creating request: %s", tc.desc, err)
+		}
+		req.Header.Set("Content-Type", tc.req.contentType)
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error sending request: %s", tc.desc, err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		transport.CloseIdleConnections()
+		srv.Close()
+
+		if res.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d got %d: %s", tc.desc, tc.status, res.StatusCode, body)
+		}
+	}
+}
@@ -27,7 +27,7 @@ func LoggingMiddleware(svc agent.Service, logger log.Logger) agent.Service {
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) Run(ctx context.Context, cmp agent.Computation) (response string, err error) {
+func (lm *loggingMiddleware) Run(ctx context.Context, cmp agent.Computation) (response agent.Computation, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method Run for computation %s took %s to complete", cmp.ID, time.Since(begin))
 		if err != nil {
@@ -40,7 +40,7 @@ func (lm *loggingMiddleware) Run(ctx context.Context, cmp agent.Computation) (re
 	return lm.svc.Run(ctx, cmp)
 }
 
-func (lm *loggingMiddleware) Algo(ctx context.Context, algorithm []byte) (response string, err error) {
+func (lm *loggingMiddleware) Algo(ctx context.Context, algorithm agent.Artifact) (response string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method Algo took %s to complete", time.Since(begin))
 		if err != nil {
@@ -53,7 +53,7 @@ func (lm *loggingMiddleware) Algo(ctx context.Context, algorithm []byte) (respon
 	return lm.svc.Algo(ctx, algorithm)
 }
 
-func (lm *loggingMiddleware) Data(ctx context.Context, dataset []byte) (response string, err error) {
+func (lm *loggingMiddleware) Data(ctx context.Context, dataset agent.Artifact) (response string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method Data took %s to complete", time.Since(begin))
 		if err != nil {
@@ -66,6 +66,71 @@ func (lm *loggingMiddleware) Data(ctx context.Context, dataset []byte) (response
 	return lm.svc.Data(ctx, dataset)
 }
 
+func (lm *loggingMiddleware) UploadAlgorithm(ctx context.Context, upload agent.Upload) (response agent.UploadProgress, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method UploadAlgorithm of %s from offset %d took %s to complete", upload.ID, upload.Offset, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors, %d of %d bytes received", message, response.Received, response.Size))
+	}(time.Now())
+
+	return lm.svc.UploadAlgorithm(ctx, upload)
+}
+
+func (lm *loggingMiddleware) UploadDataset(ctx context.Context, upload agent.Upload) (response agent.UploadProgress, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method UploadDataset of %s from offset %d took %s to complete", upload.ID, upload.Offset, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors, %d of %d bytes received", message, response.Received, response.Size))
+	}(time.Now())
+
+	return lm.svc.UploadDataset(ctx, upload)
+}
+
+func (lm *loggingMiddleware) UploadProgress(ctx context.Context, id string) (response agent.UploadProgress, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method UploadProgress of %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.UploadProgress(ctx, id)
+}
+
+func (lm *loggingMiddleware) ProvideKey(ctx context.Context, id string, key []byte) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method ProvideKey for artifact %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.ProvideKey(ctx, id, key)
+}
+
+func (lm *loggingMiddleware) Provenance(ctx context.Context) (response []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method Provenance took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.Provenance(ctx)
+}
+
 func (lm *loggingMiddleware) Result(ctx context.Context) (response []byte, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method Result took %s to complete", time.Since(begin))
@@ -79,7 +144,7 @@ func (lm *loggingMiddleware) Result(ctx context.Context) (response []byte, err e
 	return lm.svc.Result(ctx)
 }
 
-func (lm *loggingMiddleware) Attestation(ctx context.Context) (response []byte, err error) {
+func (lm *loggingMiddleware) Attestation(ctx context.Context, nonce []byte) (response []byte, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method Attestation took %s to complete", time.Since(begin))
 		if err != nil {
@@ -89,5 +154,44 @@ func (lm *loggingMiddleware) Attestation(ctx context.Context) (response []byte,
 		lm.logger.Info(fmt.Sprintf("%s without errors", message))
 	}(time.Now())
 
-	return lm.svc.Attestation(ctx)
+	return lm.svc.Attestation(ctx, nonce)
+}
+
+func (lm *loggingMiddleware) Status(ctx context.Context) (response agent.RunStatus, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method Status took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.Status(ctx)
+}
+
+func (lm *loggingMiddleware) Logs(ctx context.Context, follow bool) (response <-chan agent.LogEntry, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method Logs with follow %t took %s to complete", follow, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.Logs(ctx, follow)
+}
+
+func (lm *loggingMiddleware) Subscribe(ctx context.Context) (response <-chan agent.Event, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method Subscribe took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors", message))
+	}(time.Now())
+
+	return lm.svc.Subscribe(ctx)
 }
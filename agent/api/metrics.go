@@ -32,7 +32,7 @@ func MetricsMiddleware(svc agent.Service, counter metrics.Counter, latency metri
 	}
 }
 
-func (ms *metricsMiddleware) Run(ctx context.Context, cmp agent.Computation) (string, error) {
+func (ms *metricsMiddleware) Run(ctx context.Context, cmp agent.Computation) (agent.Computation, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "run").Add(1)
 		ms.latency.With("method", "run").Observe(time.Since(begin).Seconds())
@@ -41,7 +41,7 @@ func (ms *metricsMiddleware) Run(ctx context.Context, cmp agent.Computation) (st
 	return ms.svc.Run(ctx, cmp)
 }
 
-func (ms *metricsMiddleware) Algo(ctx context.Context, algorithm []byte) (string, error) {
+func (ms *metricsMiddleware) Algo(ctx context.Context, algorithm agent.Artifact) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "algo").Add(1)
 		ms.latency.With("method", "algo").Observe(time.Since(begin).Seconds())
@@ -50,7 +50,7 @@ func (ms *metricsMiddleware) Algo(ctx context.Context, algorithm []byte) (string
 	return ms.svc.Algo(ctx, algorithm)
 }
 
-func (ms *metricsMiddleware) Data(ctx context.Context, dataset []byte) (string, error) {
+func (ms *metricsMiddleware) Data(ctx context.Context, dataset agent.Artifact) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "data").Add(1)
 		ms.latency.With("method", "data").Observe(time.Since(begin).Seconds())
@@ -59,6 +59,51 @@ func (ms *metricsMiddleware) Data(ctx context.Context, dataset []byte) (string,
 	return ms.svc.Data(ctx, dataset)
 }
 
+func (ms *metricsMiddleware) UploadAlgorithm(ctx context.Context, upload agent.Upload) (agent.UploadProgress, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "upload_algorithm").Add(1)
+		ms.latency.With("method", "upload_algorithm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UploadAlgorithm(ctx, upload)
+}
+
+func (ms *metricsMiddleware) UploadDataset(ctx context.Context, upload agent.Upload) (agent.UploadProgress, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "upload_dataset").Add(1)
+		ms.latency.With("method", "upload_dataset").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UploadDataset(ctx, upload)
+}
+
+func (ms *metricsMiddleware) UploadProgress(ctx context.Context, id string) (agent.UploadProgress, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "upload_progress").Add(1)
+		ms.latency.With("method", "upload_progress").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UploadProgress(ctx, id)
+}
+
+func (ms *metricsMiddleware) ProvideKey(ctx context.Context, id string, key []byte) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "provide_key").Add(1)
+		ms.latency.With("method", "provide_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ProvideKey(ctx, id, key)
+}
+
+func (ms *metricsMiddleware) Provenance(ctx context.Context) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "provenance").Add(1)
+		ms.latency.With("method", "provenance").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Provenance(ctx)
+}
+
 func (ms *metricsMiddleware) Result(ctx context.Context) ([]byte, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "result").Add(1)
@@ -68,11 +113,38 @@ func (ms *metricsMiddleware) Result(ctx context.Context) ([]byte, error) {
 	return ms.svc.Result(ctx)
 }
 
-func (ms *metricsMiddleware) Attestation(ctx context.Context) ([]byte, error) {
+func (ms *metricsMiddleware) Attestation(ctx context.Context, nonce []byte) ([]byte, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "attestation").Add(1)
 		ms.latency.With("method", "attestation").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Attestation(ctx)
+	return ms.svc.Attestation(ctx, nonce)
+}
+
+func (ms *metricsMiddleware) Status(ctx context.Context) (agent.RunStatus, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "status").Add(1)
+		ms.latency.With("method", "status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Status(ctx)
+}
+
+func (ms *metricsMiddleware) Logs(ctx context.Context, follow bool) (<-chan agent.LogEntry, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "logs").Add(1)
+		ms.latency.With("method", "logs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Logs(ctx, follow)
+}
+
+func (ms *metricsMiddleware) Subscribe(ctx context.Context) (<-chan agent.Event, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "subscribe").Add(1)
+		ms.latency.With("method", "subscribe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Subscribe(ctx)
 }
@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type (
+	identitiesKey   struct{}
+	attestedTLSKey  struct{}
+	tlsPublicKeyKey struct{}
+)
+
+// CertificateIdentities returns the identities of the party authenticated
+// with cert: the hex encoded SHA-256 fingerprint of its DER encoded public
+// key and, if the certificate was verified against a trusted authority, its
+// subject common name.
+func CertificateIdentities(cert *x509.Certificate, verified bool) []string {
+	fingerprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	identities := []string{hex.EncodeToString(fingerprint[:])}
+	if verified && cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+
+	return identities
+}
+
+// TLSIdentities returns the identities of the client of the TLS connection,
+// or nil if the client did not present a certificate.
+func TLSIdentities(state tls.ConnectionState) []string {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return CertificateIdentities(state.PeerCertificates[0], len(state.VerifiedChains) > 0)
+}
+
+// WithIdentities returns a copy of ctx carrying the identities of the
+// caller, against which the parties declared in the manifest are matched.
+func WithIdentities(ctx context.Context, identities []string) context.Context {
+	return context.WithValue(ctx, identitiesKey{}, identities)
+}
+
+// WithAttestedTLS returns a copy of ctx recording that the request was
+// received over TLS with the attested certificate of the agent. Each
+// transport sets it according to its own TLS configuration, since keys of
+// encrypted artifacts are only accepted over attested TLS.
+func WithAttestedTLS(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attestedTLSKey{}, true)
+}
+
+// overAttestedTLS reports whether the request was received over attested
+// TLS.
+func overAttestedTLS(ctx context.Context) bool {
+	attested, _ := ctx.Value(attestedTLSKey{}).(bool)
+	return attested
+}
+
+// WithTLSPublicKey returns a copy of ctx carrying the DER encoded public key
+// of the TLS certificate the request was received with. Attestation reports
+// are bound to this key, and each transport sets it according to its own
+// certificate, since the gRPC and HTTP servers may serve different ones.
+func WithTLSPublicKey(ctx context.Context, publicKey []byte) context.Context {
+	return context.WithValue(ctx, tlsPublicKeyKey{}, publicKey)
+}
+
+// requestTLSPublicKey returns the public key of the TLS certificate the
+// request was received with, or nil if it was not received over TLS.
+func requestTLSPublicKey(ctx context.Context) []byte {
+	publicKey, _ := ctx.Value(tlsPublicKeyKey{}).([]byte)
+	return publicKey
+}
+
+// authorize checks that the caller has one of the identities listed in
+// parties. An empty list authorizes no one.
+func authorize(ctx context.Context, parties []string, role string) error {
+	if _, ok := matchParty(ctx, parties); ok {
+		return nil
+	}
+
+	return fmt.Errorf("%w: caller is not a declared %s", ErrUnauthorizedAccess, role)
+}
+
+// matchParty returns the entry of parties matching one of the identities of
+// the caller.
+func matchParty(ctx context.Context, parties []string) (string, bool) {
+	identities, _ := ctx.Value(identitiesKey{}).([]string)
+	for _, party := range parties {
+		for _, id := range identities {
+			if strings.EqualFold(party, id) {
+				return party, true
+			}
+		}
+	}
+
+	return "", false
+}
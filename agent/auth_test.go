@@ -0,0 +1,235 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		desc    string
+		ctx     context.Context
+		parties []string
+		err     error
+	}{
+		{
+			desc:    "authorize declared party",
+			ctx:     asParty("alice"),
+			parties: []string{"bob", "alice"},
+		},
+		{
+			desc:    "authorize declared party in other case",
+			ctx:     asParty("ALICE"),
+			parties: []string{"alice"},
+		},
+		{
+			desc:    "authorize undeclared party",
+			ctx:     asParty("carol"),
+			parties: []string{"alice", "bob"},
+			err:     ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "authorize caller without identities",
+			ctx:     context.Background(),
+			parties: []string{"alice"},
+			err:     ErrUnauthorizedAccess,
+		},
+		{
+			desc: "authorize without declared parties",
+			ctx:  asParty("alice"),
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "authorize caller without identities and without declared parties",
+			ctx:  context.Background(),
+			err:  ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := authorize(tc.ctx, tc.parties, "party")
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestCertificateIdentities(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "alice"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error parsing certificate: %s", err)
+	}
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	cases := []struct {
+		desc     string
+		verified bool
+		want     []string
+	}{
+		{desc: "identities of unverified certificate", want: []string{fingerprint}},
+		{desc: "identities of verified certificate", verified: true, want: []string{fingerprint, "alice"}},
+	}
+
+	for _, tc := range cases {
+		if got := CertificateIdentities(cert, tc.verified); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected identities %v got %v", tc.desc, tc.want, got)
+		}
+	}
+}
+
+func TestServiceAuthorization(t *testing.T) {
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	if _, err := svc.Run(asParty(testProvider), testManifest()); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	stranger := asParty("stranger")
+
+	cases := []struct {
+		desc string
+		call func() error
+		err  error
+	}{
+		{
+			desc: "upload algorithm as undeclared provider",
+			call: func() error { _, err := svc.Algo(stranger, Artifact{Content: testAlgorithm}); return err },
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "upload dataset as undeclared provider",
+			call: func() error { _, err := svc.Data(stranger, Artifact{Content: testDataset}); return err },
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "upload dataset in chunks as undeclared provider",
+			call: func() error {
+				_, err := svc.UploadDataset(stranger, Upload{ID: digest(testDataset), Size: int64(len(testDataset)), Content: bytes.NewReader(testDataset)})
+				return err
+			},
+			err: ErrUnauthorizedAccess,
+		},
+		{
+			desc: "fetch result as undeclared consumer",
+			call: func() error { _, err := svc.Result(stranger); return err },
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "fetch provenance as undeclared consumer",
+			call: func() error { _, err := svc.Provenance(stranger); return err },
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "read logs without declared log readers",
+			call: func() error { _, err := svc.Logs(asParty(testConsumer), false); return err },
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "fetch status as undeclared party",
+			call: func() error { _, err := svc.Status(stranger); return err },
+		},
+		{
+			desc: "subscribe as undeclared party",
+			call: func() error {
+				ctx, cancel := context.WithCancel(stranger)
+				defer cancel()
+				_, err := svc.Subscribe(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.call()
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestAttestationTLSPublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	svc, err := New(Config{
+		WorkDir:     t.TempDir(),
+		UploadDir:   t.TempDir(),
+		Attestation: attestation.NewMockProvider(key, [attestation.MeasurementSize]byte{}, [attestation.HostDataSize]byte{}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %s", err)
+	}
+	nonce := bytes.Repeat([]byte{1}, attestation.NonceSize)
+
+	cases := []struct {
+		desc      string
+		ctx       context.Context
+		publicKey []byte
+	}{
+		{
+			desc:      "request attestation over gRPC",
+			ctx:       WithTLSPublicKey(context.Background(), []byte("grpc key")),
+			publicKey: []byte("grpc key"),
+		},
+		{
+			desc:      "request attestation over HTTP",
+			ctx:       WithTLSPublicKey(context.Background(), []byte("http key")),
+			publicKey: []byte("http key"),
+		},
+		{
+			desc: "request attestation without TLS",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tc := range cases {
+		raw, err := svc.Attestation(tc.ctx, nonce)
+		if err != nil {
+			t.Fatalf("%s: unexpected error requesting attestation: %s", tc.desc, err)
+		}
+		report, err := attestation.ParseReport(raw)
+		if err != nil {
+			t.Fatalf("%s: unexpected error parsing report: %s", tc.desc, err)
+		}
+		want, err := attestation.ReportData(nonce, tc.publicKey)
+		if err != nil {
+			t.Fatalf("%s: unexpected error computing report data: %s", tc.desc, err)
+		}
+		if report.ReportData != want {
+			t.Errorf("%s: expected report data bound to %q", tc.desc, tc.publicKey)
+		}
+	}
+}
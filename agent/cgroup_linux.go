@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package agent
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	cpuPeriod      = 100000
+	minCPUQuota    = 1000
+	removeAttempts = 50
+	removeInterval = 20 * time.Millisecond
+)
+
+// cgroup is the cgroup v2 in which the algorithms of a computation run.
+type cgroup struct {
+	dir string
+}
+
+// newCgroup creates a cgroup named name under the parent directory and
+// applies the limits to it. The parent must be a cgroup v2 directory in
+// which the agent may enable controllers, i.e. one without processes of
+// its own or the root cgroup.
+func newCgroup(parent, name string, limits Limits) (*cgroup, error) {
+	if parent == "" {
+		parent = cgroupRoot
+	}
+
+	var controllers []string
+	files := map[string]string{}
+	if limits.Memory > 0 {
+		controllers = append(controllers, "+memory")
+		files["memory.max"] = strconv.FormatInt(limits.Memory, 10)
+		files["memory.oom.group"] = "1"
+	}
+	if limits.CPUs > 0 {
+		quota := int64(limits.CPUs * cpuPeriod)
+		if quota < minCPUQuota {
+			quota = minCPUQuota
+		}
+		controllers = append(controllers, "+cpu")
+		files["cpu.max"] = fmt.Sprintf("%d %d", quota, cpuPeriod)
+	}
+	if limits.Pids > 0 {
+		controllers = append(controllers, "+pids")
+		files["pids.max"] = strconv.FormatInt(limits.Pids, 10)
+	}
+
+	subtree := filepath.Join(parent, "cgroup.subtree_control")
+	if err := os.WriteFile(subtree, []byte(strings.Join(controllers, " ")), 0); err != nil {
+		return nil, fmt.Errorf("error enabling cgroup controllers in %s: %w", parent, err)
+	}
+
+	cg := &cgroup{dir: filepath.Join(parent, name)}
+	if err := os.Mkdir(cg.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cgroup: %w", err)
+	}
+	for file, value := range files {
+		if err := cg.write(file, value); err != nil {
+			_ = cg.remove()
+			return nil, err
+		}
+	}
+	if limits.Memory > 0 {
+		// The file only exists when swap accounting is enabled.
+		if err := cg.write("memory.swap.max", "0"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			_ = cg.remove()
+			return nil, err
+		}
+	}
+
+	return cg, nil
+}
+
+func (cg *cgroup) write(file, value string) error {
+	if err := os.WriteFile(filepath.Join(cg.dir, file), []byte(value), 0); err != nil {
+		return fmt.Errorf("error setting %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// exceeded returns the error describing the limit the algorithms ran into,
+// or nil if no limit was hit.
+func (cg *cgroup) exceeded() error {
+	if n, _ := cg.event("memory.events", "oom_kill"); n > 0 {
+		return ErrMemoryLimit
+	}
+	if n, _ := cg.event("pids.events", "max"); n > 0 {
+		return ErrPidsLimit
+	}
+
+	return nil
+}
+
+// event returns the value of the key in a cgroup events file.
+func (cg *cgroup) event(file, key string) (int64, error) {
+	f, err := os.Open(filepath.Join(cg.dir, file))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+
+	return 0, scanner.Err()
+}
+
+// remove kills the processes left in the cgroup and deletes it.
+func (cg *cgroup) remove() error {
+	// cgroup.kill is only available since Linux 5.14. Without it, the
+	// processes left behind are killed together with the PID namespace of
+	// the sandbox, if any.
+	_ = cg.write("cgroup.kill", "1")
+
+	var err error
+	for i := 0; i < removeAttempts; i++ {
+		// Killed processes leave the cgroup asynchronously.
+		if err = os.Remove(cg.dir); err == nil || errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		time.Sleep(removeInterval)
+	}
+
+	return fmt.Errorf("error removing cgroup: %w", err)
+}
+
+// placeInCgroup makes cmd start directly in the cgroup at dir.
+func placeInCgroup(cmd *exec.Cmd, dir string) (*os.File, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error opening cgroup: %w", err)
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(f.Fd())
+
+	return f, nil
+}
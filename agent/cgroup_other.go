@@ -0,0 +1,35 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+// +build !linux
+
+package agent
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+var errCgroupUnsupported = errors.New("resource limits are not supported on this platform")
+
+type cgroup struct {
+	dir string
+}
+
+func newCgroup(parent, name string, limits Limits) (*cgroup, error) {
+	return nil, errCgroupUnsupported
+}
+
+func (cg *cgroup) exceeded() error {
+	return nil
+}
+
+func (cg *cgroup) remove() error {
+	return nil
+}
+
+func placeInCgroup(cmd *exec.Cmd, dir string) (*os.File, error) {
+	return nil, errCgroupUnsupported
+}
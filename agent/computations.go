@@ -15,6 +15,11 @@ type Computation struct {
 	DatasetProviders   []string  `json:"dataset_providers,omitempty" db:"dataset_providers"`
 	AlgorithmProviders []string  `json:"algorithm_providers,omitempty" db:"algorithm_providers"`
 	ResultConsumers    []string  `json:"result_consumers,omitempty" db:"result_consumers"`
+	ResultConsumerKeys []string  `json:"result_consumer_keys,omitempty" db:"result_consumer_keys"`
+	LogReaders         []string  `json:"log_readers,omitempty" db:"log_readers"`
+	Runtime            string    `json:"runtime,omitempty" db:"runtime"`
+	Sandbox            *Sandbox  `json:"sandbox,omitempty" db:"sandbox"`
+	Limits             *Limits   `json:"limits,omitempty" db:"limits"`
 	Ttl                int32     `json:"ttl,omitempty" db:"ttl"`
 	Metadata           Metadata  `json:"metadata,omitempty" db:"metadata"`
 }
@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the error details attached to gRPC statuses.
+const ErrorDomain = "agent.ultraviolet.rs"
+
+// Kind classifies the errors of the service, so that transports map them to
+// their status codes and clients can tell which errors are worth retrying.
+type Kind int
+
+const (
+	// KindInternal is the kind of unexpected errors of the agent.
+	KindInternal Kind = iota
+	// KindMalformedEntity is the kind of invalid requests and manifests.
+	KindMalformedEntity
+	// KindUnauthorized is the kind of requests of undeclared parties.
+	KindUnauthorized
+	// KindNotFound is the kind of requests for undeclared artifacts and
+	// unconfigured features.
+	KindNotFound
+	// KindWrongState is the kind of requests not permitted in the current
+	// state of the computation.
+	KindWrongState
+	// KindLimitExceeded is the kind of computations exceeding a limit.
+	KindLimitExceeded
+	// KindRuntimeFailure is the kind of computations failing at runtime.
+	KindRuntimeFailure
+)
+
+// kinds lists the errors of each kind. Limits and runtime failures come
+// first, since the error of a failed computation wraps its cause.
+var kinds = []struct {
+	kind Kind
+	errs []error
+}{
+	{KindLimitExceeded, []error{ErrTimeout, ErrMemoryLimit, ErrPidsLimit, ErrResultTooLarge}},
+	{KindRuntimeFailure, []error{ErrComputationFailed, ErrDecryption}},
+	{KindMalformedEntity, []error{ErrMalformedEntity}},
+	{KindUnauthorized, []error{ErrUnauthorizedAccess}},
+	{KindNotFound, []error{ErrNotFound, ErrUndeclaredAlgorithm, ErrUndeclaredDataset, errNoAttestationProvider, errNoProvenanceKey}},
+	{KindWrongState, []error{ErrWrongState, ErrAlreadyUploaded, ErrUploadOffset, ErrWiped}},
+}
+
+// ErrorKind returns the kind of err, or KindInternal if err is not an error
+// of the service.
+func ErrorKind(err error) Kind {
+	for _, k := range kinds {
+		for _, e := range k.errs {
+			if errors.Is(err, e) {
+				return k.kind
+			}
+		}
+	}
+
+	return KindInternal
+}
+
+func (k Kind) String() string {
+	switch k {
+	case KindMalformedEntity:
+		return "MALFORMED_ENTITY"
+	case KindUnauthorized:
+		return "UNAUTHORIZED"
+	case KindNotFound:
+		return "NOT_FOUND"
+	case KindWrongState:
+		return "WRONG_STATE"
+	case KindLimitExceeded:
+		return "LIMIT_EXCEEDED"
+	case KindRuntimeFailure:
+		return "RUNTIME_FAILURE"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// Code returns the gRPC status code of errors of the kind.
+func (k Kind) Code() codes.Code {
+	switch k {
+	case KindMalformedEntity:
+		return codes.InvalidArgument
+	case KindUnauthorized:
+		return codes.PermissionDenied
+	case KindNotFound:
+		return codes.NotFound
+	case KindWrongState:
+		return codes.FailedPrecondition
+	case KindLimitExceeded:
+		return codes.ResourceExhausted
+	case KindRuntimeFailure:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
+
+// HTTPStatus returns the HTTP status of errors of the kind.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindMalformedEntity:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindWrongState:
+		return http.StatusConflict
+	case KindLimitExceeded, KindRuntimeFailure:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Status returns the gRPC status of err. The status carries the kind of the
+// error as the reason of its error details. Errors which already carry a
+// status, such as those of a broken stream, and context errors keep their
+// codes.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	kind := ErrorKind(err)
+	st := status.New(kind.Code(), err.Error())
+	if detailed, derr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: kind.String(),
+		Domain: ErrorDomain,
+	}); derr == nil {
+		st = detailed
+	}
+
+	return st
+}
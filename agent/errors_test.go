@@ -0,0 +1,124 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorReason returns the reason of the error details of st, or an empty
+// string if it carries none.
+func errorReason(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+func TestStatus(t *testing.T) {
+	cases := []struct {
+		desc   string
+		err    error
+		code   codes.Code
+		reason string
+		msg    string
+	}{
+		{
+			desc:   "malformed entity",
+			err:    ErrMalformedEntity,
+			code:   codes.InvalidArgument,
+			reason: "MALFORMED_ENTITY",
+			msg:    ErrMalformedEntity.Error(),
+		},
+		{
+			desc:   "wrapped unauthorized access",
+			err:    fmt.Errorf("%w: caller is not a declared party", ErrUnauthorizedAccess),
+			code:   codes.PermissionDenied,
+			reason: "UNAUTHORIZED",
+			msg:    ErrUnauthorizedAccess.Error() + ": caller is not a declared party",
+		},
+		{
+			desc:   "wrapped undeclared dataset",
+			err:    fmt.Errorf("%w: %s", ErrUndeclaredDataset, "digest"),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+			msg:    ErrUndeclaredDataset.Error() + ": digest",
+		},
+		{
+			desc:   "wiped result",
+			err:    ErrWiped,
+			code:   codes.FailedPrecondition,
+			reason: "WRONG_STATE",
+			msg:    ErrWiped.Error(),
+		},
+		{
+			desc:   "failed computation wrapping its timeout",
+			err:    fmt.Errorf("%w: %w", ErrComputationFailed, ErrTimeout),
+			code:   codes.ResourceExhausted,
+			reason: "LIMIT_EXCEEDED",
+			msg:    ErrComputationFailed.Error() + ": " + ErrTimeout.Error(),
+		},
+		{
+			desc:   "failed computation",
+			err:    fmt.Errorf("%w: exit status 1", ErrComputationFailed),
+			code:   codes.Aborted,
+			reason: "RUNTIME_FAILURE",
+			msg:    ErrComputationFailed.Error() + ": exit status 1",
+		},
+		{
+			desc:   "unexpected error",
+			err:    errors.New("unexpected"),
+			code:   codes.Internal,
+			reason: "INTERNAL",
+			msg:    "unexpected",
+		},
+		{
+			desc: "canceled context",
+			err:  context.Canceled,
+			code: codes.Canceled,
+			msg:  context.Canceled.Error(),
+		},
+		{
+			desc: "wrapped exceeded context deadline",
+			err:  fmt.Errorf("reading upload: %w", context.DeadlineExceeded),
+			code: codes.DeadlineExceeded,
+			msg:  "reading upload: " + context.DeadlineExceeded.Error(),
+		},
+		{
+			desc: "error carrying a status",
+			err:  status.Error(codes.Unavailable, "connection closed"),
+			code: codes.Unavailable,
+			msg:  "connection closed",
+		},
+		{
+			desc: "wrapped error carrying a status",
+			err:  fmt.Errorf("%w: %w", ErrMalformedEntity, status.Error(codes.Unavailable, "connection closed")),
+			code: codes.Unavailable,
+			msg:  ErrMalformedEntity.Error() + ": rpc error: code = Unavailable desc = connection closed",
+		},
+	}
+
+	for _, tc := range cases {
+		st := Status(tc.err)
+		if st.Code() != tc.code {
+			t.Errorf("%s: expected code %s got %s", tc.desc, tc.code, st.Code())
+		}
+		if reason := errorReason(st); reason != tc.reason {
+			t.Errorf("%s: expected reason %q got %q", tc.desc, tc.reason, reason)
+		}
+		if st.Message() != tc.msg {
+			t.Errorf("%s: expected message %q got %q", tc.desc, tc.msg, st.Message())
+		}
+	}
+}
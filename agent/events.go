@@ -0,0 +1,160 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies a step in the progress of the computation.
+type EventType uint8
+
+const (
+	// EventManifestReceived is published when the manifest is accepted.
+	EventManifestReceived EventType = iota + 1
+	// EventAlgorithmUploaded is published when an algorithm is uploaded.
+	EventAlgorithmUploaded
+	// EventDatasetUploaded is published when a dataset is uploaded.
+	EventDatasetUploaded
+	// EventRunStarted is published when all inputs are present and the
+	// computation starts.
+	EventRunStarted
+	// EventProgress is published when an algorithm of the computation
+	// starts running.
+	EventProgress
+	// EventRunFinished is published when the computation produces a result.
+	EventRunFinished
+	// EventRunFailed is published when the computation fails.
+	EventRunFailed
+	// EventResultFetched is published when a result consumer retrieves the
+	// result.
+	EventResultFetched
+	// EventDataWiped is published when the result and the logs are wiped.
+	// It is the last event of the computation.
+	EventDataWiped
+)
+
+var eventTypeNames = map[EventType]string{
+	EventManifestReceived:  "MANIFEST_RECEIVED",
+	EventAlgorithmUploaded: "ALGORITHM_UPLOADED",
+	EventDatasetUploaded:   "DATASET_UPLOADED",
+	EventRunStarted:        "RUN_STARTED",
+	EventProgress:          "PROGRESS",
+	EventRunFinished:       "RUN_FINISHED",
+	EventRunFailed:         "RUN_FAILED",
+	EventResultFetched:     "RESULT_FETCHED",
+	EventDataWiped:         "DATA_WIPED",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// Event reports a step in the progress of the computation.
+type Event struct {
+	Type          EventType
+	ComputationID string
+	Time          time.Time
+	// ArtifactID is the digest of the uploaded algorithm or dataset.
+	ArtifactID string
+	// Step is the position of the running algorithm among the Steps
+	// algorithms of the computation, starting from 1.
+	Step  int
+	Steps int
+	// Error describes why the computation failed.
+	Error string
+}
+
+// eventLog keeps the events of the computation and notifies subscribers of
+// new ones. A computation publishes a few events per artifact and per
+// algorithm, so all of them are kept.
+type eventLog struct {
+	mu     sync.Mutex
+	events []Event
+	closed bool
+	// notify is closed and replaced whenever an event is published or the
+	// log is closed.
+	notify chan struct{}
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{notify: make(chan struct{})}
+}
+
+func (el *eventLog) publish(event Event) {
+	event.Time = time.Now()
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if el.closed {
+		return
+	}
+	el.events = append(el.events, event)
+	el.wake()
+}
+
+// close marks the end of the events. Subscribers return once they have
+// received the remaining events.
+func (el *eventLog) close() {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if !el.closed {
+		el.closed = true
+		el.wake()
+	}
+}
+
+// wake notifies the subscribers. It must be called with el.mu held.
+func (el *eventLog) wake() {
+	close(el.notify)
+	el.notify = make(chan struct{})
+}
+
+// since returns the events starting from index i.
+func (el *eventLog) since(i int) (events []Event, closed bool, notify <-chan struct{}) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	return append(events, el.events[i:]...), el.closed, el.notify
+}
+
+// stream sends the published events to the returned channel, followed by
+// new ones until the log is closed. The channel is closed once streaming is
+// done or ctx is canceled.
+func (el *eventLog) stream(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+
+		var next int
+		for {
+			events, closed, notify := el.since(next)
+			for _, event := range events {
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			next += len(events)
+			if closed {
+				return
+			}
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
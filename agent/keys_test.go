@@ -0,0 +1,182 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ultravioletrs/agent/pkg/envelope"
+)
+
+var (
+	testKey      = bytes.Repeat([]byte{1}, envelope.ArtifactKeySize)
+	testOtherKey = bytes.Repeat([]byte{2}, envelope.ArtifactKeySize)
+)
+
+// asAttestedParty returns the context of a request of the party received
+// over attested TLS, over which keys are accepted.
+func asAttestedParty(party string) context.Context {
+	return WithAttestedTLS(asParty(party))
+}
+
+// newEncryptedTestService returns the service with the manifest running and
+// the algorithm uploaded encrypted with testKey.
+func newEncryptedTestService(t *testing.T) *agentService {
+	t.Helper()
+
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	if _, err := svc.Run(asParty(testProvider), testManifest()); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	ciphertext, err := envelope.EncryptArtifact(testKey, testAlgorithm, digest(testAlgorithm))
+	if err != nil {
+		t.Fatalf("unexpected error encrypting algorithm: %s", err)
+	}
+	if _, err := svc.Algo(asParty(testProvider), Artifact{Content: ciphertext, ID: digest(testAlgorithm)}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+
+	return svc
+}
+
+func TestProvideKey(t *testing.T) {
+	svc := newEncryptedTestService(t)
+	provider := asAttestedParty(testProvider)
+
+	steps := []struct {
+		desc string
+		ctx  context.Context
+		id   string
+		key  []byte
+		err  error
+	}{
+		{
+			desc: "provide key as undeclared provider",
+			ctx:  asAttestedParty("stranger"),
+			id:   digest(testAlgorithm),
+			key:  testKey,
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "provide key of undeclared artifact",
+			ctx:  provider,
+			id:   digest([]byte("other")),
+			key:  testKey,
+			err:  ErrNotFound,
+		},
+		{
+			desc: "provide short key",
+			ctx:  provider,
+			id:   digest(testAlgorithm),
+			key:  testKey[1:],
+			err:  ErrMalformedEntity,
+		},
+		{
+			desc: "provide key",
+			ctx:  provider,
+			id:   digest(testAlgorithm),
+			key:  testKey,
+		},
+		{
+			desc: "provide key again",
+			ctx:  provider,
+			id:   digest(testAlgorithm),
+			key:  testKey,
+			err:  ErrAlreadyUploaded,
+		},
+	}
+
+	for _, step := range steps {
+		err := svc.ProvideKey(step.ctx, step.id, step.key)
+		if !errors.Is(err, step.err) || (step.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", step.desc, step.err, err)
+		}
+	}
+
+	if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+	if status := waitState(t, svc); status.State != Finished {
+		t.Errorf("expected state %s got %s (%s)", Finished, status.State, status.Error)
+	}
+}
+
+func TestProvideKeyWithoutAttestedTLS(t *testing.T) {
+	svc := newEncryptedTestService(t)
+
+	err := svc.ProvideKey(asParty(testProvider), digest(testAlgorithm), testKey)
+	if !errors.Is(err, ErrUnauthorizedAccess) {
+		t.Errorf("expected error %v got %v", ErrUnauthorizedAccess, err)
+	}
+}
+
+func TestReleasedKeyDecryption(t *testing.T) {
+	cases := []struct {
+		desc  string
+		key   []byte
+		state State
+	}{
+		{desc: "run with released key", key: testKey, state: Finished},
+		{desc: "run with wrong key", key: testOtherKey, state: Failed},
+	}
+
+	for _, tc := range cases {
+		svc := newEncryptedTestService(t)
+		provider := asAttestedParty(testProvider)
+
+		if err := svc.ProvideKey(provider, digest(testAlgorithm), tc.key); err != nil {
+			t.Fatalf("%s: unexpected error providing key: %s", tc.desc, err)
+		}
+		if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+			t.Fatalf("%s: unexpected error uploading dataset: %s", tc.desc, err)
+		}
+		if status := waitState(t, svc); status.State != tc.state {
+			t.Errorf("%s: expected state %s got %s (%s)", tc.desc, tc.state, status.State, status.Error)
+		}
+		if tc.state != Failed {
+			continue
+		}
+		if _, err := svc.Result(asParty(testConsumer)); !errors.Is(err, ErrDecryption) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, ErrDecryption, err)
+		}
+	}
+}
+
+func TestEncryptedArtifactDigest(t *testing.T) {
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	provider := asAttestedParty(testProvider)
+	if _, err := svc.Run(provider, testManifest()); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+
+	// The ciphertext authenticates the declared ID, but its plaintext is
+	// not the declared algorithm.
+	ciphertext, err := envelope.EncryptArtifact(testKey, []byte("other"), digest(testAlgorithm))
+	if err != nil {
+		t.Fatalf("unexpected error encrypting algorithm: %s", err)
+	}
+	if _, err := svc.Algo(provider, Artifact{Content: ciphertext, ID: digest(testAlgorithm)}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+	if err := svc.ProvideKey(provider, digest(testAlgorithm), testKey); err != nil {
+		t.Fatalf("unexpected error providing key: %s", err)
+	}
+	if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+
+	if status := waitState(t, svc); status.State != Failed {
+		t.Errorf("expected state %s got %s", Failed, status.State)
+	}
+	if _, err := svc.Result(asParty(testConsumer)); !errors.Is(err, ErrDecryption) {
+		t.Errorf("expected error %v got %v", ErrDecryption, err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
+
+// Sources of the computation manifest provided at launch, other than the
+// path of a manifest file.
+const (
+	// ManifestCmdline reads the manifest from the kernel command line.
+	ManifestCmdline = "cmdline"
+	// ManifestSMBIOS reads the manifest from the SMBIOS OEM strings. Unlike
+	// the kernel command line, they are never part of the launch
+	// measurement, so the manifest is only bound to the attestation reports
+	// by its digest in the host data.
+	ManifestSMBIOS = "smbios"
+)
+
+// manifestParam is the parameter of the kernel command line or the OEM
+// string holding the base64 encoded manifest.
+const manifestParam = "agent.manifest="
+
+const (
+	cmdlineFile = "/proc/cmdline"
+	// oemStrings matches the SMBIOS structures of type 11, which hold the
+	// OEM strings.
+	oemStrings = "/sys/firmware/dmi/entries/11-*/raw"
+)
+
+var (
+	errNoLaunchManifest = errors.New("no agent.manifest parameter found")
+	// ErrHostDataMismatch indicates that the host data of the attestation
+	// reports is not the digest of the manifest provided at launch.
+	ErrHostDataMismatch = errors.New("host data does not match launch manifest")
+)
+
+// ReadLaunchManifest reads the computation manifest provided at launch from
+// the source, which is ManifestCmdline, ManifestSMBIOS or the path of a
+// file. The file holds the manifest in JSON, as sent to the HTTP API, while
+// the kernel command line and the OEM strings carry it base64 encoded in an
+// agent.manifest parameter. It returns the manifest together with the
+// SHA-256 digest of its JSON encoding, as checked by CheckLaunchManifest.
+func ReadLaunchManifest(source string) (Computation, [sha256.Size]byte, error) {
+	var data []byte
+	var err error
+	switch source {
+	case ManifestCmdline:
+		data, err = cmdlineManifest()
+	case ManifestSMBIOS:
+		data, err = smbiosManifest()
+	default:
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return Computation{}, [sha256.Size]byte{}, err
+	}
+
+	var cmp Computation
+	if err := json.Unmarshal(data, &cmp); err != nil {
+		return Computation{}, [sha256.Size]byte{}, fmt.Errorf("%w: %v", ErrMalformedEntity, err)
+	}
+
+	return cmp, sha256.Sum256(data), nil
+}
+
+// CheckLaunchManifest checks that the attestation reports of the agent carry
+// the digest of the manifest provided at launch as their host data, which
+// the host sets when it launches the VM. Verifiers expecting the digest in
+// the host data of a report are then assured that the agent runs the
+// manifest, whichever source it was read from.
+func CheckLaunchManifest(provider attestation.Provider, digest [sha256.Size]byte) error {
+	if provider == nil {
+		return errNoAttestationProvider
+	}
+	raw, err := provider.Report([attestation.ReportDataSize]byte{})
+	if err != nil {
+		return fmt.Errorf("error producing attestation report: %w", err)
+	}
+	report, err := attestation.ParseReport(raw)
+	if err != nil {
+		return err
+	}
+	if report.HostData != digest {
+		return fmt.Errorf("%w: host data %x differs from manifest digest %x", ErrHostDataMismatch, report.HostData, digest)
+	}
+
+	return nil
+}
+
+func cmdlineManifest() ([]byte, error) {
+	cmdline, err := os.ReadFile(cmdlineFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range strings.Fields(string(cmdline)) {
+		if value, ok := strings.CutPrefix(param, manifestParam); ok {
+			return decodeManifestParam(value)
+		}
+	}
+
+	return nil, fmt.Errorf("%w in %s", errNoLaunchManifest, cmdlineFile)
+}
+
+func smbiosManifest() ([]byte, error) {
+	entries, err := filepath.Glob(oemStrings)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		raw, err := os.ReadFile(entry)
+		if err != nil {
+			return nil, err
+		}
+		// The strings follow the formatted area of the structure, whose
+		// length is in the second byte, and are terminated by a NUL.
+		if len(raw) < 2 || int(raw[1]) > len(raw) {
+			continue
+		}
+		for _, s := range bytes.Split(raw[raw[1]:], []byte{0}) {
+			if value, ok := strings.CutPrefix(string(s), manifestParam); ok {
+				return decodeManifestParam(value)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w in SMBIOS OEM strings", errNoLaunchManifest)
+}
+
+func decodeManifestParam(value string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: manifest is not base64 encoded: %v", ErrMalformedEntity, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
+
+func TestReadLaunchManifest(t *testing.T) {
+	manifest, err := json.Marshal(testManifest())
+	if err != nil {
+		t.Fatalf("unexpected error encoding manifest: %s", err)
+	}
+
+	cases := []struct {
+		desc    string
+		content []byte
+		err     error
+	}{
+		{
+			desc:    "read manifest",
+			content: manifest,
+		},
+		{
+			desc:    "read malformed manifest",
+			content: manifest[1:],
+			err:     ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "manifest.json")
+		if err := os.WriteFile(path, tc.content, filePerm); err != nil {
+			t.Fatalf("%s: unexpected error writing manifest: %s", tc.desc, err)
+		}
+
+		cmp, digest, err := ReadLaunchManifest(path)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if cmp.ID != testManifest().ID {
+			t.Errorf("%s: expected computation %s got %s", tc.desc, testManifest().ID, cmp.ID)
+		}
+		if digest != sha256.Sum256(tc.content) {
+			t.Errorf("%s: expected digest %x got %x", tc.desc, sha256.Sum256(tc.content), digest)
+		}
+	}
+}
+
+func TestCheckLaunchManifest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	digest := sha256.Sum256([]byte("manifest"))
+
+	cases := []struct {
+		desc     string
+		provider attestation.Provider
+		err      error
+	}{
+		{
+			desc:     "check manifest digest in host data",
+			provider: attestation.NewMockProvider(key, [attestation.MeasurementSize]byte{}, digest),
+		},
+		{
+			desc:     "check manifest against other host data",
+			provider: attestation.NewMockProvider(key, [attestation.MeasurementSize]byte{}, sha256.Sum256([]byte("other"))),
+			err:      ErrHostDataMismatch,
+		},
+		{
+			desc: "check manifest without attestation provider",
+			err:  errNoAttestationProvider,
+		},
+	}
+
+	for _, tc := range cases {
+		err := CheckLaunchManifest(tc.provider, digest)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
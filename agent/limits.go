@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxResultSize is the maximum size of an algorithm result in
+	// bytes used when the agent configuration does not set one.
+	DefaultMaxResultSize = 64 << 20
+
+	// cgroupRoot is the default parent of the per-computation cgroups.
+	cgroupRoot = "/sys/fs/cgroup"
+)
+
+// Limits configures the resources available to the algorithms of a
+// computation. Zero values leave the corresponding resource unlimited.
+// Memory, CPU and process limits are enforced through a cgroup v2 created
+// for every computation; the wasm runtime only enforces the memory limit.
+type Limits struct {
+	// Memory is the maximum memory usage in bytes. Swap is disabled when it
+	// is set.
+	Memory int64 `json:"memory,omitempty"`
+	// CPUs is the maximum CPU bandwidth in number of CPUs, e.g. 1.5.
+	CPUs float64 `json:"cpus,omitempty"`
+	// Pids is the maximum number of processes and threads.
+	Pids int64 `json:"pids,omitempty"`
+}
+
+// cgroup reports whether any of the limits is enforced through a cgroup.
+func (l Limits) cgroup() bool {
+	return l.Memory > 0 || l.CPUs > 0 || l.Pids > 0
+}
+
+// ttl returns the wall-clock time limit of the computation, or zero if the
+// manifest does not set one. Ttl is expressed in seconds.
+func ttl(cmp Computation) time.Duration {
+	return time.Duration(cmp.Ttl) * time.Second
+}
@@ -0,0 +1,181 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// StreamStdout identifies log entries written to standard output.
+	StreamStdout = "stdout"
+	// StreamStderr identifies log entries written to standard error.
+	StreamStderr = "stderr"
+
+	// DefaultLogBufferSize is the number of bytes of algorithm output kept
+	// per computation when the agent configuration does not set one.
+	DefaultLogBufferSize = 1 << 20
+)
+
+// LogEntry is a chunk of output written by an algorithm.
+type LogEntry struct {
+	Stream string
+	Time   time.Time
+	Data   []byte
+}
+
+// logBuffer keeps the most recent output of the algorithms of a computation
+// within a fixed number of bytes and notifies followers of new entries.
+type logBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	// first is the sequence number of entries[0].
+	first  uint64
+	size   int
+	limit  int
+	closed bool
+	// notify is closed and replaced whenever an entry is added or the buffer
+	// is closed.
+	notify chan struct{}
+}
+
+func newLogBuffer(limit int) *logBuffer {
+	return &logBuffer{
+		limit:  limit,
+		notify: make(chan struct{}),
+	}
+}
+
+// writer returns a writer appending entries of the given stream.
+func (lb *logBuffer) writer(stream string) io.Writer {
+	return streamWriter{buffer: lb, stream: stream}
+}
+
+func (lb *logBuffer) append(stream string, p []byte) {
+	if len(p) > lb.limit {
+		p = p[len(p)-lb.limit:]
+	}
+	entry := LogEntry{
+		Stream: stream,
+		Time:   time.Now(),
+		Data:   append([]byte{}, p...),
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if lb.closed {
+		return
+	}
+	lb.entries = append(lb.entries, entry)
+	lb.size += len(entry.Data)
+	for lb.size > lb.limit {
+		lb.size -= len(lb.entries[0].Data)
+		lb.entries[0] = LogEntry{}
+		lb.entries = lb.entries[1:]
+		lb.first++
+	}
+	lb.wake()
+}
+
+// close marks the end of the output. Followers return once they have
+// received the remaining entries.
+func (lb *logBuffer) close() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if !lb.closed {
+		lb.closed = true
+		lb.wake()
+	}
+}
+
+// wipe zeroes and drops the buffered entries and closes the buffer.
+func (lb *logBuffer) wipe() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	for i := range lb.entries {
+		zero(lb.entries[i].Data)
+		lb.entries[i] = LogEntry{}
+	}
+	lb.first += uint64(len(lb.entries))
+	lb.entries = nil
+	lb.size = 0
+	if !lb.closed {
+		lb.closed = true
+		lb.wake()
+	}
+}
+
+// wake notifies the followers. It must be called with lb.mu held.
+func (lb *logBuffer) wake() {
+	close(lb.notify)
+	lb.notify = make(chan struct{})
+}
+
+// since returns copies of the entries starting from sequence number seq, or
+// from the oldest entry kept if older ones were dropped, together with the
+// sequence number following them. The data is copied so that wiping the
+// buffer does not alter entries handed to followers.
+func (lb *logBuffer) since(seq uint64) (entries []LogEntry, next uint64, closed bool, notify <-chan struct{}) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if seq < lb.first {
+		seq = lb.first
+	}
+	for _, entry := range lb.entries[seq-lb.first:] {
+		entry.Data = append([]byte{}, entry.Data...)
+		entries = append(entries, entry)
+	}
+
+	return entries, lb.first + uint64(len(lb.entries)), lb.closed, lb.notify
+}
+
+// stream sends the buffered entries to the returned channel. With follow
+// set, it keeps sending new entries until the buffer is closed. The channel
+// is closed once streaming is done or ctx is canceled.
+func (lb *logBuffer) stream(ctx context.Context, follow bool) <-chan LogEntry {
+	ch := make(chan LogEntry)
+	go func() {
+		defer close(ch)
+
+		var seq uint64
+		for {
+			entries, next, closed, notify := lb.since(seq)
+			for _, entry := range entries {
+				select {
+				case ch <- entry:
+				case <-ctx.Done():
+					return
+				}
+			}
+			seq = next
+			if !follow || closed {
+				return
+			}
+			select {
+			case <-notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+type streamWriter struct {
+	buffer *logBuffer
+	stream string
+}
+
+func (sw streamWriter) Write(p []byte) (int, error) {
+	sw.buffer.append(sw.stream, p)
+	return len(p), nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// readLogs returns the data of the entries received from ch until it is
+// closed.
+func readLogs(t *testing.T, ch <-chan LogEntry) []string {
+	t.Helper()
+
+	var data []string
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case entry, ok := <-ch:
+			if !ok {
+				return data
+			}
+			data = append(data, string(entry.Data))
+		case <-timeout:
+			t.Fatalf("timed out reading logs, got %q", data)
+		}
+	}
+}
+
+func TestLogBuffer(t *testing.T) {
+	cases := []struct {
+		desc   string
+		limit  int
+		writes []string
+		want   []string
+	}{
+		{
+			desc:   "keep all entries within limit",
+			limit:  16,
+			writes: []string{"first", "second"},
+			want:   []string{"first", "second"},
+		},
+		{
+			desc:   "drop oldest entries over limit",
+			limit:  8,
+			writes: []string{"first", "second", "third"},
+			want:   []string{"third"},
+		},
+		{
+			desc:   "truncate entry over limit",
+			limit:  4,
+			writes: []string{"second"},
+			want:   []string{"cond"},
+		},
+	}
+
+	for _, tc := range cases {
+		lb := newLogBuffer(tc.limit)
+		for _, w := range tc.writes {
+			if _, err := lb.writer(StreamStdout).Write([]byte(w)); err != nil {
+				t.Fatalf("%s: unexpected error writing logs: %s", tc.desc, err)
+			}
+		}
+
+		got := readLogs(t, lb.stream(context.Background(), false))
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: expected entries %q got %q", tc.desc, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: expected entries %q got %q", tc.desc, tc.want, got)
+				break
+			}
+		}
+	}
+}
+
+func TestLogBufferFollow(t *testing.T) {
+	lb := newLogBuffer(DefaultLogBufferSize)
+	ch := lb.stream(context.Background(), true)
+
+	lb.writer(StreamStdout).Write([]byte("first"))
+	lb.writer(StreamStderr).Write([]byte("second"))
+	lb.close()
+	// Entries written after the buffer is closed are discarded.
+	lb.writer(StreamStdout).Write([]byte("third"))
+
+	got := readLogs(t, ch)
+	if len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Errorf("expected entries %q got %q", []string{"first", "second"}, got)
+	}
+}
+
+func TestLogBufferWipe(t *testing.T) {
+	lb := newLogBuffer(DefaultLogBufferSize)
+	lb.writer(StreamStdout).Write([]byte("output"))
+
+	ch := lb.stream(context.Background(), true)
+	entry := <-ch
+	lb.wipe()
+
+	if string(entry.Data) != "output" {
+		t.Errorf("expected received entry to be kept after wipe, got %q", entry.Data)
+	}
+	if got := readLogs(t, ch); len(got) != 0 {
+		t.Errorf("expected no entries after wipe, got %q", got)
+	}
+	if got := readLogs(t, lb.stream(context.Background(), false)); len(got) != 0 {
+		t.Errorf("expected wiped buffer to be empty, got %q", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ComputationToManifest converts the computation to the protobuf message
+// exchanged over gRPC. Result consumers and their keys are paired by
+// position, so the computation declares either no keys or one key for every
+// consumer.
+func ComputationToManifest(cmp Computation) (*ComputationManifest, error) {
+	if len(cmp.ResultConsumerKeys) > 0 && len(cmp.ResultConsumerKeys) != len(cmp.ResultConsumers) {
+		return nil, fmt.Errorf("%w: %d result consumer keys declared for %d result consumers", ErrMalformedEntity, len(cmp.ResultConsumerKeys), len(cmp.ResultConsumers))
+	}
+
+	m := &ComputationManifest{
+		Id:                 cmp.ID,
+		Name:               cmp.Name,
+		Description:        cmp.Description,
+		Status:             cmp.Status,
+		Owner:              cmp.Owner,
+		StartTime:          timestamp(cmp.StartTime),
+		EndTime:            timestamp(cmp.EndTime),
+		Algorithms:         cmp.Algorithms,
+		Datasets:           cmp.Datasets,
+		AlgorithmProviders: parties(cmp.AlgorithmProviders),
+		DatasetProviders:   parties(cmp.DatasetProviders),
+		LogReaders:         parties(cmp.LogReaders),
+		Runtime:            cmp.Runtime,
+		Ttl:                cmp.Ttl,
+	}
+
+	for i, id := range cmp.ResultConsumers {
+		consumer := &ResultConsumer{Id: id}
+		if len(cmp.ResultConsumerKeys) > 0 {
+			consumer.PublicKey = cmp.ResultConsumerKeys[i]
+		}
+		m.ResultConsumers = append(m.ResultConsumers, consumer)
+	}
+
+	if sb := cmp.Sandbox; sb != nil {
+		m.Sandbox = &SandboxOptions{
+			UserNamespace:    proto.Bool(sb.UserNamespace),
+			MountNamespace:   proto.Bool(sb.MountNamespace),
+			PidNamespace:     proto.Bool(sb.PIDNamespace),
+			ReadOnlyRoot:     proto.Bool(sb.ReadOnlyRoot),
+			Seccomp:          proto.Bool(sb.Seccomp),
+			DropCapabilities: proto.Bool(sb.DropCapabilities),
+			Network:          proto.Bool(sb.Network),
+		}
+	}
+	if l := cmp.Limits; l != nil {
+		m.Limits = &ResourceLimits{
+			Memory: l.Memory,
+			Cpus:   l.CPUs,
+			Pids:   l.Pids,
+		}
+	}
+	if cmp.Metadata != nil {
+		metadata, err := structpb.NewStruct(cmp.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("%w: metadata: %v", ErrMalformedEntity, err)
+		}
+		m.Metadata = metadata
+	}
+
+	return m, nil
+}
+
+// ManifestToComputation converts the protobuf message exchanged over gRPC
+// to a computation. Sandbox features omitted from the message keep their
+// DefaultSandbox values. The fields of result consumers are kept at the
+// position of the consumer even when empty, so that the validation of the
+// computation rejects them rather than pairing consumers with the keys of
+// others.
+func ManifestToComputation(m *ComputationManifest) Computation {
+	cmp := Computation{
+		ID:                 m.GetId(),
+		Name:               m.GetName(),
+		Description:        m.GetDescription(),
+		Status:             m.GetStatus(),
+		Owner:              m.GetOwner(),
+		Algorithms:         m.GetAlgorithms(),
+		Datasets:           m.GetDatasets(),
+		AlgorithmProviders: partyIDs(m.GetAlgorithmProviders()),
+		DatasetProviders:   partyIDs(m.GetDatasetProviders()),
+		LogReaders:         partyIDs(m.GetLogReaders()),
+		Runtime:            m.GetRuntime(),
+		Ttl:                m.GetTtl(),
+	}
+	if m.GetStartTime() != nil {
+		cmp.StartTime = m.GetStartTime().AsTime()
+	}
+	if m.GetEndTime() != nil {
+		cmp.EndTime = m.GetEndTime().AsTime()
+	}
+
+	var keys bool
+	for _, consumer := range m.GetResultConsumers() {
+		cmp.ResultConsumers = append(cmp.ResultConsumers, consumer.GetId())
+		cmp.ResultConsumerKeys = append(cmp.ResultConsumerKeys, consumer.GetPublicKey())
+		keys = keys || consumer.GetPublicKey() != ""
+	}
+	if !keys {
+		cmp.ResultConsumerKeys = nil
+	}
+
+	if opts := m.GetSandbox(); opts != nil {
+		sb := DefaultSandbox()
+		setBool(&sb.UserNamespace, opts.UserNamespace)
+		setBool(&sb.MountNamespace, opts.MountNamespace)
+		setBool(&sb.PIDNamespace, opts.PidNamespace)
+		setBool(&sb.ReadOnlyRoot, opts.ReadOnlyRoot)
+		setBool(&sb.Seccomp, opts.Seccomp)
+		setBool(&sb.DropCapabilities, opts.DropCapabilities)
+		setBool(&sb.Network, opts.Network)
+		cmp.Sandbox = &sb
+	}
+	if l := m.GetLimits(); l != nil {
+		cmp.Limits = &Limits{
+			Memory: l.GetMemory(),
+			CPUs:   l.GetCpus(),
+			Pids:   l.GetPids(),
+		}
+	}
+	if m.GetMetadata() != nil {
+		cmp.Metadata = m.GetMetadata().AsMap()
+	}
+
+	return cmp
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func parties(ids []string) []*Party {
+	var ps []*Party
+	for _, id := range ids {
+		ps = append(ps, &Party{Id: id})
+	}
+	return ps
+}
+
+func partyIDs(ps []*Party) []string {
+	var ids []string
+	for _, p := range ps {
+		ids = append(ids, p.GetId())
+	}
+	return ids
+}
+
+func setBool(dst *bool, v *bool) {
+	if v != nil {
+		*dst = *v
+	}
+}
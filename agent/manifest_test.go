@@ -0,0 +1,193 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	testConsumerKey      = strings.Repeat("ab", 32)
+	testOtherConsumerKey = strings.Repeat("cd", 32)
+)
+
+func TestManifestSchema(t *testing.T) {
+	cases := []struct {
+		desc   string
+		modify func(cmp *Computation)
+		err    error
+	}{
+		{
+			desc:   "valid manifest",
+			modify: func(cmp *Computation) {},
+		},
+		{
+			desc:   "manifest without algorithms",
+			modify: func(cmp *Computation) { cmp.Algorithms = nil },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with short algorithm digest",
+			modify: func(cmp *Computation) { cmp.Algorithms = []string{cmp.Algorithms[0][1:]} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with duplicate algorithm",
+			modify: func(cmp *Computation) { cmp.Algorithms = append(cmp.Algorithms, cmp.Algorithms[0]) },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest without datasets",
+			modify: func(cmp *Computation) { cmp.Datasets, cmp.DatasetProviders = nil, nil },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest without algorithm providers",
+			modify: func(cmp *Computation) { cmp.AlgorithmProviders = nil },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with empty algorithm provider",
+			modify: func(cmp *Computation) { cmp.AlgorithmProviders = []string{""} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest without result consumers",
+			modify: func(cmp *Computation) { cmp.ResultConsumers = nil },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with empty log reader",
+			modify: func(cmp *Computation) { cmp.LogReaders = []string{""} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with negative ttl",
+			modify: func(cmp *Computation) { cmp.Ttl = -1 },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with negative memory limit",
+			modify: func(cmp *Computation) { cmp.Limits = &Limits{Memory: -1} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with negative CPU limit",
+			modify: func(cmp *Computation) { cmp.Limits = &Limits{CPUs: -0.5} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc:   "manifest with limits",
+			modify: func(cmp *Computation) { cmp.Limits = &Limits{Memory: 1 << 20, CPUs: 0.5, Pids: 10} },
+		},
+		{
+			desc:   "manifest with consumer key",
+			modify: func(cmp *Computation) { cmp.ResultConsumerKeys = []string{testConsumerKey} },
+		},
+		{
+			desc:   "manifest with invalid consumer key",
+			modify: func(cmp *Computation) { cmp.ResultConsumerKeys = []string{"key"} },
+			err:    ErrMalformedEntity,
+		},
+		{
+			desc: "manifest with key of some consumers only",
+			modify: func(cmp *Computation) {
+				cmp.ResultConsumers = []string{"alice", "bob"}
+				cmp.ResultConsumerKeys = []string{testConsumerKey, ""}
+			},
+			err: ErrMalformedEntity,
+		},
+		{
+			desc: "manifest with more consumer keys than consumers",
+			modify: func(cmp *Computation) {
+				cmp.ResultConsumerKeys = []string{testConsumerKey, testOtherConsumerKey}
+			},
+			err: ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		cmp := testManifest()
+		tc.modify(&cmp)
+
+		err := validateManifest(cmp)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestManifestResultConsumers(t *testing.T) {
+	cases := []struct {
+		desc      string
+		consumers []*ResultConsumer
+		ids       []string
+		keys      []string
+		err       error
+	}{
+		{
+			desc:      "consumers with keys",
+			consumers: []*ResultConsumer{{Id: "alice", PublicKey: testConsumerKey}, {Id: "bob", PublicKey: testOtherConsumerKey}},
+			ids:       []string{"alice", "bob"},
+			keys:      []string{testConsumerKey, testOtherConsumerKey},
+		},
+		{
+			desc:      "consumers without keys",
+			consumers: []*ResultConsumer{{Id: "alice"}, {Id: "bob"}},
+			ids:       []string{"alice", "bob"},
+		},
+		{
+			desc:      "consumer without key before consumer with key",
+			consumers: []*ResultConsumer{{Id: "alice"}, {Id: "bob", PublicKey: testOtherConsumerKey}},
+			ids:       []string{"alice", "bob"},
+			keys:      []string{"", testOtherConsumerKey},
+			err:       ErrMalformedEntity,
+		},
+		{
+			desc:      "consumer without identity before consumer with identity",
+			consumers: []*ResultConsumer{{PublicKey: testConsumerKey}, {Id: "bob", PublicKey: testOtherConsumerKey}},
+			ids:       []string{"", "bob"},
+			keys:      []string{testConsumerKey, testOtherConsumerKey},
+			err:       ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		m, err := ComputationToManifest(testManifest())
+		if err != nil {
+			t.Fatalf("%s: unexpected error converting computation: %s", tc.desc, err)
+		}
+		m.ResultConsumers = tc.consumers
+
+		cmp := ManifestToComputation(m)
+		if !reflect.DeepEqual(cmp.ResultConsumers, tc.ids) || !reflect.DeepEqual(cmp.ResultConsumerKeys, tc.keys) {
+			t.Errorf("%s: expected consumers %q with keys %q got %q with %q", tc.desc, tc.ids, tc.keys, cmp.ResultConsumers, cmp.ResultConsumerKeys)
+		}
+		err = validateManifest(cmp)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	cmp := testManifest()
+	cmp.ResultConsumerKeys = []string{testConsumerKey}
+	cmp.LogReaders = []string{testConsumer}
+	sb := DefaultSandbox()
+	cmp.Sandbox = &sb
+	cmp.Limits = &Limits{Memory: 1 << 20, CPUs: 0.5, Pids: 10}
+	cmp.Ttl = 60
+
+	m, err := ComputationToManifest(cmp)
+	if err != nil {
+		t.Fatalf("unexpected error converting computation: %s", err)
+	}
+	if got := ManifestToComputation(m); !reflect.DeepEqual(got, cmp) {
+		t.Errorf("expected computation %+v got %+v", cmp, got)
+	}
+}
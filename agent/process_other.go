@@ -0,0 +1,18 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+// +build !linux
+
+package agent
+
+import "os/exec"
+
+// setProcessGroup is a no-op on platforms without process group support.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the algorithm process only, since its children
+// are not tracked on this platform.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
@@ -0,0 +1,249 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	socket "github.com/ultravioletrs/agent/pkg"
+)
+
+const (
+	// RuntimePython executes algorithms as Python 3 scripts. It is used when
+	// the manifest does not specify a runtime.
+	RuntimePython = "python"
+	// RuntimeBinary executes algorithms as native Linux ELF binaries.
+	RuntimeBinary = "binary"
+	// RuntimeShell executes algorithms as POSIX shell scripts.
+	RuntimeShell = "shell"
+
+	pythonInterpreter = "python3"
+	shellInterpreter  = "/bin/sh"
+	binaryPerm        = 0o500
+	// outputWaitDelay bounds the time spent copying the output of the
+	// algorithm after it exits, in case it left children holding it open.
+	outputWaitDelay = time.Second
+)
+
+var (
+	errNotELF   = errors.New("algorithm is not an ELF binary")
+	errNoResult = errors.New("algorithm exited without sending a result")
+	elfMagic    = []byte{0x7f, 'E', 'L', 'F'}
+)
+
+// Runtime executes a single algorithm of the computation.
+type Runtime interface {
+	// Run executes the algorithm described by the task and returns the
+	// result it produced.
+	Run(ctx context.Context, task Task) ([]byte, error)
+}
+
+// Task describes a single algorithm execution.
+type Task struct {
+	// Dir is the working directory of the algorithm.
+	Dir string
+	// Algorithm is the path of the algorithm file.
+	Algorithm string
+	// Inputs are the paths of the dataset files followed, for chained
+	// algorithms, by the path of the result of the previous algorithm.
+	Inputs []string
+	// Socket is the path of the Unix socket the algorithm sends its result
+	// to.
+	Socket string
+	// Sandbox isolates the algorithm process when set.
+	Sandbox *Sandbox
+	// Limits are the resource limits of the computation, if any.
+	Limits *Limits
+	// Cgroup is the path of the cgroup v2 directory the algorithm process
+	// is started in when set.
+	Cgroup string
+	// MaxResultSize is the maximum size of the result in bytes.
+	MaxResultSize int64
+	// Stdout and Stderr receive the output of the algorithm when set.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// runtimes returns the built-in runtimes indexed by the name used in the
+// computation manifest.
+func runtimes() map[string]Runtime {
+	return map[string]Runtime{
+		RuntimePython: NewPythonRuntime(),
+		RuntimeBinary: NewBinaryRuntime(),
+		RuntimeShell:  NewShellRuntime(),
+		RuntimeWasm:   NewWasmRuntime(),
+	}
+}
+
+// processRuntime executes algorithms as child processes which send their
+// result over a Unix socket.
+type processRuntime struct {
+	name    string
+	command func(task Task) (*exec.Cmd, error)
+}
+
+var _ Runtime = (*processRuntime)(nil)
+
+// NewPythonRuntime returns a runtime executing algorithms as Python 3
+// scripts invoked as `python3 <algorithm> <inputs...> <socket>`.
+func NewPythonRuntime() Runtime {
+	return &processRuntime{
+		name: RuntimePython,
+		command: func(task Task) (*exec.Cmd, error) {
+			args := append([]string{task.Algorithm}, task.Inputs...)
+			cmd := exec.Command(pythonInterpreter, append(args, task.Socket)...)
+			cmd.Env = runtimeEnv("PYTHONUNBUFFERED=1", "PYTHONDONTWRITEBYTECODE=1")
+			return cmd, nil
+		},
+	}
+}
+
+// NewBinaryRuntime returns a runtime executing algorithms as native Linux
+// ELF binaries invoked as `<algorithm> <inputs...> <socket>`.
+func NewBinaryRuntime() Runtime {
+	return &processRuntime{
+		name: RuntimeBinary,
+		command: func(task Task) (*exec.Cmd, error) {
+			header := make([]byte, len(elfMagic))
+			f, err := os.Open(task.Algorithm)
+			if err != nil {
+				return nil, err
+			}
+			_, err = f.Read(header)
+			f.Close()
+			if err != nil || !bytes.Equal(header, elfMagic) {
+				return nil, errNotELF
+			}
+			if err := os.Chmod(task.Algorithm, binaryPerm); err != nil {
+				return nil, err
+			}
+
+			args := append(append([]string{}, task.Inputs...), task.Socket)
+			cmd := exec.Command(task.Algorithm, args...)
+			cmd.Env = runtimeEnv()
+			return cmd, nil
+		},
+	}
+}
+
+// NewShellRuntime returns a runtime executing algorithms as POSIX shell
+// scripts invoked as `/bin/sh <algorithm> <inputs...> <socket>`.
+func NewShellRuntime() Runtime {
+	return &processRuntime{
+		name: RuntimeShell,
+		command: func(task Task) (*exec.Cmd, error) {
+			args := append([]string{task.Algorithm}, task.Inputs...)
+			cmd := exec.Command(shellInterpreter, append(args, task.Socket)...)
+			cmd.Env = runtimeEnv()
+			return cmd, nil
+		},
+	}
+}
+
+// runtimeEnv returns the environment of the algorithm process. Algorithms
+// do not inherit the environment of the agent.
+func runtimeEnv(vars ...string) []string {
+	return append([]string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"}, vars...)
+}
+
+func (pr *processRuntime) Run(ctx context.Context, task Task) ([]byte, error) {
+	listener, err := socket.StartUnixSocketServer(task.Socket)
+	if err != nil {
+		return nil, fmt.Errorf("error creating result socket: %v", err)
+	}
+	defer listener.Close()
+
+	// Create channels for received data and errors. They are buffered so
+	// that the socket goroutine never blocks once the run is abandoned.
+	dataChannel := make(chan []byte, 1)
+	errorChannel := make(chan error, 1)
+	go socket.AcceptConnection(listener, task.MaxResultSize, dataChannel, errorChannel)
+
+	cmd, err := pr.command(task)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing %s algorithm: %w", pr.name, err)
+	}
+	cmd.Dir = task.Dir
+	cmd.Stdout = task.Stdout
+	cmd.Stderr = task.Stderr
+	cmd.WaitDelay = outputWaitDelay
+	if task.Sandbox != nil {
+		if err := task.Sandbox.apply(cmd); err != nil {
+			return nil, fmt.Errorf("error sandboxing %s algorithm: %w", pr.name, err)
+		}
+	}
+	if task.Cgroup != "" {
+		cgroupFile, err := placeInCgroup(cmd, task.Cgroup)
+		if err != nil {
+			return nil, err
+		}
+		defer cgroupFile.Close()
+	}
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("error starting %s algorithm: %w", pr.name, err)
+	}
+
+	waitChannel := make(chan error, 1)
+	go func() {
+		waitChannel <- cmd.Wait()
+	}()
+
+	select {
+	case receivedData := <-dataChannel:
+		// The algorithm may keep running after sending its result, so the
+		// deadline still applies until it exits.
+		select {
+		case err = <-waitChannel:
+		case <-ctx.Done():
+			killProcessGroup(cmd)
+			<-waitChannel
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s algorithm execution error: %w", pr.name, err)
+		}
+		return receivedData, nil
+	case err = <-errorChannel:
+		killProcessGroup(cmd)
+		<-waitChannel
+		if errors.Is(err, socket.ErrDataTooLarge) {
+			return nil, resultTooLarge(task)
+		}
+		return nil, fmt.Errorf("error receiving data: %v", err)
+	case err = <-waitChannel:
+		if err != nil {
+			return nil, fmt.Errorf("%s algorithm execution error: %w", pr.name, err)
+		}
+	case <-ctx.Done():
+		killProcessGroup(cmd)
+		<-waitChannel
+		return nil, ctx.Err()
+	}
+
+	// The algorithm exited successfully; closing the listener unblocks a
+	// pending accept if the algorithm never connected to the socket.
+	listener.Close()
+	select {
+	case receivedData := <-dataChannel:
+		return receivedData, nil
+	case err = <-errorChannel:
+		if errors.Is(err, socket.ErrDataTooLarge) {
+			return nil, resultTooLarge(task)
+		}
+		return nil, errNoResult
+	}
+}
+
+func resultTooLarge(task Task) error {
+	return fmt.Errorf("%w: limit is %d bytes", ErrResultTooLarge, task.MaxResultSize)
+}
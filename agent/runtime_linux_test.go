@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// lingeringAlgorithm starts a child which records its PID, sends the result
+// and keeps running.
+const lingeringAlgorithm = `import socket, subprocess, sys, time
+child = subprocess.Popen(["sleep", "60"])
+with open("child.pid", "w") as f:
+    f.write(str(child.pid))
+s = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+s.connect(sys.argv[-1])
+s.sendall(b"result")
+s.close()
+time.sleep(60)
+`
+
+// alive reports whether the process exists and is not a zombie.
+func alive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	// The state follows the command name, which is in parentheses.
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func TestProcessRuntimeDeadline(t *testing.T) {
+	if _, err := exec.LookPath(pythonInterpreter); err != nil {
+		t.Skipf("%s is not available", pythonInterpreter)
+	}
+
+	dir := t.TempDir()
+	algorithm := filepath.Join(dir, "algorithm.py")
+	if err := os.WriteFile(algorithm, []byte(lingeringAlgorithm), 0o600); err != nil {
+		t.Fatalf("unexpected error writing algorithm: %s", err)
+	}
+	task := Task{
+		Dir:           dir,
+		Algorithm:     algorithm,
+		Socket:        filepath.Join(dir, "result.sock"),
+		MaxResultSize: DefaultMaxResultSize,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	result, err := NewPythonRuntime().Run(ctx, task)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error %v got %v (result %q)", context.DeadlineExceeded, err, result)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the algorithm to be killed at the deadline, ran for %s", elapsed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "child.pid"))
+	if err != nil {
+		t.Fatalf("unexpected error reading child PID: %s", err)
+	}
+	pid, err := strconv.Atoi(string(data))
+	if err != nil {
+		t.Fatalf("unexpected child PID %q: %s", data, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for alive(pid) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if alive(pid) {
+		_ = syscall.Kill(pid, syscall.SIGKILL)
+		t.Error("expected the child of the algorithm to be killed at the deadline")
+	}
+}
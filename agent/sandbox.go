@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var errSandboxUnsupported = errors.New("algorithm sandbox is not supported on this platform")
+
+// Sandbox configures the isolation of algorithm processes. Sandboxing is
+// opt-in: it is applied only when the computation manifest contains a
+// sandbox object. Every isolation feature is enabled unless the manifest
+// explicitly disables it, and network access is denied unless the manifest
+// explicitly allows it. The sandbox does not apply to the wasm runtime, which
+// is isolated by the WebAssembly runtime itself.
+type Sandbox struct {
+	// UserNamespace runs the algorithm in a new user namespace in which it is
+	// root, mapped to the user running the agent.
+	UserNamespace bool `json:"user_namespace"`
+	// MountNamespace runs the algorithm in a new mount namespace.
+	MountNamespace bool `json:"mount_namespace"`
+	// PIDNamespace runs the algorithm in a new PID namespace.
+	PIDNamespace bool `json:"pid_namespace"`
+	// ReadOnlyRoot replaces the root filesystem seen by the algorithm with a
+	// read-only view containing only the system directories, the workspace
+	// and private /dev, /proc and /tmp. It requires MountNamespace.
+	ReadOnlyRoot bool `json:"read_only_root"`
+	// Seccomp restricts the algorithm to an allow-list of system calls.
+	Seccomp bool `json:"seccomp"`
+	// DropCapabilities clears all capabilities of the algorithm and forbids
+	// it from gaining new privileges.
+	DropCapabilities bool `json:"drop_capabilities"`
+	// Network allows the algorithm to access the network. When false, the
+	// algorithm runs in a new network namespace without any interfaces.
+	Network bool `json:"network"`
+}
+
+// DefaultSandbox returns the sandbox with every isolation feature enabled
+// and no network access.
+func DefaultSandbox() Sandbox {
+	return Sandbox{
+		UserNamespace:    true,
+		MountNamespace:   true,
+		PIDNamespace:     true,
+		ReadOnlyRoot:     true,
+		Seccomp:          true,
+		DropCapabilities: true,
+		Network:          false,
+	}
+}
+
+// UnmarshalJSON decodes the sandbox on top of DefaultSandbox so that
+// omitted features stay enabled.
+func (sb *Sandbox) UnmarshalJSON(data []byte) error {
+	// sandbox has the same fields without the custom unmarshaler.
+	type sandbox Sandbox
+	s := sandbox(DefaultSandbox())
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*sb = Sandbox(s)
+
+	return nil
+}
+
+func (sb Sandbox) validate() error {
+	if sb.ReadOnlyRoot && !sb.MountNamespace {
+		return fmt.Errorf("%w: read-only root requires a mount namespace", ErrMalformedEntity)
+	}
+
+	return nil
+}
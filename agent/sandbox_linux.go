@@ -0,0 +1,338 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// sandboxInitName is the argv[0] under which the agent binary is
+	// re-executed to set up the sandbox before executing the algorithm.
+	sandboxInitName = "cocos-sandbox-init"
+	sandboxEnv      = "COCOS_SANDBOX"
+	sandboxSelf     = "/proc/self/exe"
+	sandboxRootName = "rootfs-*"
+	oldRootName     = ".oldroot"
+	initFailureCode = 125
+
+	// Filesystem flags reported by statfs. They are not exported by
+	// golang.org/x/sys/unix.
+	stRdonly     = 0x1
+	stNosuid     = 0x2
+	stNodev      = 0x4
+	stNoexec     = 0x8
+	stNoatime    = 0x400
+	stNodiratime = 0x800
+	stRelatime   = 0x1000
+)
+
+var (
+	// systemDirs are bind mounted read-only into the sandbox root.
+	systemDirs = []string{"/bin", "/sbin", "/usr", "/lib", "/lib32", "/lib64", "/etc"}
+	// devices are bind mounted into the sandbox /dev.
+	devices = []string{"/dev/null", "/dev/zero", "/dev/random", "/dev/urandom"}
+)
+
+// sandboxConfig is passed to the sandbox init process through the
+// environment.
+type sandboxConfig struct {
+	Sandbox Sandbox `json:"sandbox"`
+	Dir     string  `json:"dir"`
+	Root    string  `json:"root,omitempty"`
+}
+
+// apply rewrites cmd so that the agent binary is re-executed as the sandbox
+// init process in new namespaces. The init process isolates itself and then
+// executes the original command.
+func (sb Sandbox) apply(cmd *exec.Cmd) error {
+	cfg := sandboxConfig{
+		Sandbox: sb,
+		Dir:     cmd.Dir,
+	}
+	if sb.ReadOnlyRoot {
+		root, err := os.MkdirTemp(cmd.Dir, sandboxRootName)
+		if err != nil {
+			return fmt.Errorf("error creating sandbox root: %w", err)
+		}
+		cfg.Root = root
+	}
+
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	cmd.Args = append([]string{sandboxInitName, cmd.Path}, cmd.Args...)
+	cmd.Path = sandboxSelf
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", sandboxEnv, cfgJSON))
+
+	attr := &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	if sb.UserNamespace {
+		attr.Cloneflags |= syscall.CLONE_NEWUSER
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+		attr.GidMappingsEnableSetgroups = false
+	}
+	if sb.MountNamespace {
+		attr.Cloneflags |= syscall.CLONE_NEWNS
+	}
+	if sb.PIDNamespace {
+		attr.Cloneflags |= syscall.CLONE_NEWPID
+	}
+	if !sb.Network {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = attr
+
+	return nil
+}
+
+// SandboxInit must be called at the very beginning of the agent's main
+// function. When the process was started as the sandbox init process, it
+// isolates the process as configured by the computation manifest and
+// replaces it with the algorithm, so it never returns. Otherwise it returns
+// immediately.
+func SandboxInit() {
+	if len(os.Args) < 3 || os.Args[0] != sandboxInitName {
+		return
+	}
+
+	// Credentials and seccomp filters are per thread, so the whole setup and
+	// the final exec have to happen on the same thread.
+	runtime.LockOSThread()
+
+	if err := sandboxInit(); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %s\n", err)
+		os.Exit(initFailureCode)
+	}
+}
+
+func sandboxInit() error {
+	var cfg sandboxConfig
+	if err := json.Unmarshal([]byte(os.Getenv(sandboxEnv)), &cfg); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	var env []string
+	for _, v := range os.Environ() {
+		if !strings.HasPrefix(v, sandboxEnv+"=") {
+			env = append(env, v)
+		}
+	}
+
+	sb := cfg.Sandbox
+	if sb.MountNamespace {
+		if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+			return fmt.Errorf("error making mounts private: %w", err)
+		}
+		switch {
+		case sb.ReadOnlyRoot:
+			if err := pivotReadOnlyRoot(cfg); err != nil {
+				return err
+			}
+		case sb.PIDNamespace:
+			if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+				return fmt.Errorf("error mounting /proc: %w", err)
+			}
+		}
+	}
+
+	if err := os.Chdir(cfg.Dir); err != nil {
+		return err
+	}
+
+	if sb.DropCapabilities || sb.Seccomp {
+		if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+			return fmt.Errorf("error setting no_new_privs: %w", err)
+		}
+	}
+	if sb.DropCapabilities {
+		if err := dropCapabilities(); err != nil {
+			return err
+		}
+	}
+	if sb.Seccomp {
+		if err := installSeccomp(); err != nil {
+			return err
+		}
+	}
+
+	return syscall.Exec(os.Args[1], os.Args[2:], env)
+}
+
+// pivotReadOnlyRoot replaces the root filesystem with a read-only tmpfs
+// holding read-only bind mounts of the system directories and the
+// workspace, and private /dev, /proc and /tmp.
+func pivotReadOnlyRoot(cfg sandboxConfig) error {
+	root := cfg.Root
+	if err := unix.Mount("tmpfs", root, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=0755"); err != nil {
+		return fmt.Errorf("error mounting sandbox root: %w", err)
+	}
+
+	for _, dir := range systemDirs {
+		fi, err := os.Lstat(dir)
+		if err != nil {
+			continue
+		}
+		target := filepath.Join(root, dir)
+		if fi.Mode()&os.ModeSymlink != 0 {
+			// Merged /usr layouts link /bin, /lib etc. into /usr.
+			link, err := os.Readlink(dir)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := bindReadOnly(dir, target, true); err != nil {
+			return err
+		}
+	}
+
+	for _, dev := range devices {
+		target := filepath.Join(root, dev)
+		if err := os.MkdirAll(filepath.Dir(target), dirPerm); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, filePerm)
+		if err != nil {
+			return err
+		}
+		f.Close()
+		if err := unix.Mount(dev, target, "", unix.MS_BIND, ""); err != nil {
+			return fmt.Errorf("error mounting %s: %w", dev, err)
+		}
+	}
+
+	if cfg.Sandbox.PIDNamespace {
+		target := filepath.Join(root, "proc")
+		if err := os.MkdirAll(target, dirPerm); err != nil {
+			return err
+		}
+		if err := unix.Mount("proc", target, "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+			return fmt.Errorf("error mounting /proc: %w", err)
+		}
+	}
+
+	tmp := filepath.Join(root, "tmp")
+	if err := os.MkdirAll(tmp, dirPerm); err != nil {
+		return err
+	}
+	if err := unix.Mount("tmpfs", tmp, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("error mounting /tmp: %w", err)
+	}
+
+	// The workspace is mounted last since it may be located under one of
+	// the directories mounted above, such as /tmp.
+	if err := bindReadOnly(cfg.Dir, filepath.Join(root, cfg.Dir), false); err != nil {
+		return err
+	}
+
+	oldRoot := filepath.Join(root, oldRootName)
+	if err := os.Mkdir(oldRoot, dirPerm); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(root, oldRoot); err != nil {
+		return fmt.Errorf("error pivoting root: %w", err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+	if err := unix.Unmount("/"+oldRootName, unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("error unmounting old root: %w", err)
+	}
+	if err := os.Remove("/" + oldRootName); err != nil {
+		return err
+	}
+
+	if err := unix.Mount("", "/", "", unix.MS_REMOUNT|unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("error remounting root read-only: %w", err)
+	}
+
+	return nil
+}
+
+// bindReadOnly bind mounts source to target and remounts it read-only,
+// preserving the flags of the source mount which cannot be cleared from
+// within a user namespace.
+func bindReadOnly(source, target string, recursive bool) error {
+	if err := os.MkdirAll(target, dirPerm); err != nil {
+		return err
+	}
+
+	flags := uintptr(unix.MS_BIND)
+	if recursive {
+		flags |= unix.MS_REC
+	}
+	if err := unix.Mount(source, target, "", flags, ""); err != nil {
+		return fmt.Errorf("error mounting %s: %w", source, err)
+	}
+
+	var st unix.Statfs_t
+	if err := unix.Statfs(target, &st); err != nil {
+		return err
+	}
+	flags = unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY | mountFlags(int64(st.Flags))
+	if err := unix.Mount("", target, "", flags, ""); err != nil {
+		return fmt.Errorf("error remounting %s read-only: %w", source, err)
+	}
+
+	return nil
+}
+
+// mountFlags converts statfs flags to the corresponding mount flags.
+func mountFlags(statFlags int64) uintptr {
+	var flags uintptr
+	for st, ms := range map[int64]uintptr{
+		stRdonly:     unix.MS_RDONLY,
+		stNosuid:     unix.MS_NOSUID,
+		stNodev:      unix.MS_NODEV,
+		stNoexec:     unix.MS_NOEXEC,
+		stNoatime:    unix.MS_NOATIME,
+		stNodiratime: unix.MS_NODIRATIME,
+		stRelatime:   unix.MS_RELATIME,
+	} {
+		if statFlags&st != 0 {
+			flags |= ms
+		}
+	}
+
+	return flags
+}
+
+// dropCapabilities removes all capabilities from the bounding, ambient,
+// effective, permitted and inheritable sets of the calling thread.
+func dropCapabilities() error {
+	for c := 0; c <= unix.CAP_LAST_CAP; c++ {
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(c), 0, 0, 0); err != nil && err != unix.EINVAL {
+			return fmt.Errorf("error dropping bounding capability %d: %w", c, err)
+		}
+	}
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil && err != unix.EINVAL {
+		return fmt.Errorf("error clearing ambient capabilities: %w", err)
+	}
+
+	hdr := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capset(&hdr, &data[0]); err != nil {
+		return fmt.Errorf("error clearing capabilities: %w", err)
+	}
+
+	return nil
+}
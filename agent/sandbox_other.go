@@ -0,0 +1,16 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+// +build !linux
+
+package agent
+
+import "os/exec"
+
+// SandboxInit is a no-op on platforms without sandbox support.
+func SandboxInit() {}
+
+func (sb Sandbox) apply(cmd *exec.Cmd) error {
+	return errSandboxUnsupported
+}
@@ -0,0 +1,26 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux && amd64
+// +build linux,amd64
+
+package agent
+
+import "golang.org/x/sys/unix"
+
+const (
+	auditArch = unix.AUDIT_ARCH_X86_64
+	// syscallLimit is the x32 ABI system call bit.
+	syscallLimit = 0x40000000
+)
+
+// archSyscalls extends the seccomp allow-list with the legacy system calls
+// still used on amd64.
+var archSyscalls = []uintptr{
+	unix.SYS_OPEN, unix.SYS_STAT, unix.SYS_LSTAT, unix.SYS_NEWFSTATAT, unix.SYS_ACCESS,
+	unix.SYS_PIPE, unix.SYS_SELECT, unix.SYS_POLL, unix.SYS_DUP2, unix.SYS_FORK, unix.SYS_VFORK,
+	unix.SYS_GETDENTS, unix.SYS_RENAME, unix.SYS_MKDIR, unix.SYS_RMDIR, unix.SYS_UNLINK,
+	unix.SYS_READLINK, unix.SYS_SYMLINK, unix.SYS_LINK, unix.SYS_CHMOD,
+	unix.SYS_EPOLL_CREATE, unix.SYS_EPOLL_WAIT, unix.SYS_EVENTFD, unix.SYS_GETPGRP,
+	unix.SYS_TIME, unix.SYS_ALARM, unix.SYS_PAUSE, unix.SYS_ARCH_PRCTL,
+}
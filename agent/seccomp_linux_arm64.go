@@ -0,0 +1,20 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux && arm64
+// +build linux,arm64
+
+package agent
+
+import "golang.org/x/sys/unix"
+
+const (
+	auditArch    = unix.AUDIT_ARCH_AARCH64
+	syscallLimit = 0
+)
+
+// archSyscalls extends the seccomp allow-list with the system calls
+// specific to arm64.
+var archSyscalls = []uintptr{
+	unix.SYS_FSTATAT,
+}
@@ -0,0 +1,15 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux && !amd64 && !arm64
+// +build linux,!amd64,!arm64
+
+package agent
+
+// The seccomp allow-list is only defined for amd64 and arm64.
+const (
+	auditArch    = 0
+	syscallLimit = 0
+)
+
+var archSyscalls []uintptr
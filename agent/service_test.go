@@ -0,0 +1,608 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+	"github.com/ultravioletrs/agent/pkg/envelope"
+	"github.com/ultravioletrs/agent/pkg/provenance"
+)
+
+const (
+	testRuntime  = "test"
+	testProvider = "provider"
+	testConsumer = "consumer"
+)
+
+var (
+	testAlgorithm = []byte("algorithm")
+	testDataset   = []byte("dataset")
+	testResult    = []byte("result")
+)
+
+// testRuntimeFunc is a runtime running every algorithm with a function.
+type testRuntimeFunc func(ctx context.Context, task Task) ([]byte, error)
+
+func (f testRuntimeFunc) Run(ctx context.Context, task Task) ([]byte, error) {
+	return f(ctx, task)
+}
+
+// newTestService returns the service with the runtime registered as
+// testRuntime and work and upload directories removed at the end of the
+// test.
+func newTestService(t *testing.T, rt Runtime) *agentService {
+	t.Helper()
+
+	svc, err := New(Config{WorkDir: t.TempDir(), UploadDir: t.TempDir(), ResultRetention: -1})
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %s", err)
+	}
+	as := svc.(*agentService)
+	as.runtimes[testRuntime] = rt
+
+	return as
+}
+
+// testManifest returns a manifest declaring the test artifacts, provided
+// and consumed by the test parties.
+func testManifest() Computation {
+	return Computation{
+		ID:                 "computation",
+		Algorithms:         []string{digest(testAlgorithm)},
+		Datasets:           []string{digest(testDataset)},
+		AlgorithmProviders: []string{testProvider},
+		DatasetProviders:   []string{testProvider},
+		ResultConsumers:    []string{testConsumer},
+		Runtime:            testRuntime,
+	}
+}
+
+func asParty(party string) context.Context {
+	return WithIdentities(context.Background(), []string{party})
+}
+
+// waitState waits until the computation reaches one of the final states.
+func waitState(t *testing.T, svc Service) RunStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		status, err := svc.Status(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected status error: %s", err)
+		}
+		if status.State == Finished || status.State == Failed {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("computation did not end in time")
+
+	return RunStatus{}
+}
+
+func TestLifecycle(t *testing.T) {
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	provider, consumer := asParty(testProvider), asParty(testConsumer)
+
+	steps := []struct {
+		desc  string
+		call  func() error
+		err   error
+		state State
+	}{
+		{
+			desc:  "fetch result before manifest",
+			call:  func() error { _, err := svc.Result(consumer); return err },
+			err:   ErrUnauthorizedAccess,
+			state: ReceivingManifest,
+		},
+		{
+			desc:  "upload algorithm before manifest",
+			call:  func() error { _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); return err },
+			err:   ErrUnauthorizedAccess,
+			state: ReceivingManifest,
+		},
+		{
+			desc: "run manifest without datasets",
+			call: func() error {
+				_, err := svc.Run(provider, Computation{Algorithms: []string{digest(testAlgorithm)}})
+				return err
+			},
+			err:   ErrMalformedEntity,
+			state: ReceivingManifest,
+		},
+		{
+			desc:  "run manifest",
+			call:  func() error { _, err := svc.Run(provider, testManifest()); return err },
+			state: ReceivingAlgorithms,
+		},
+		{
+			desc:  "run manifest twice",
+			call:  func() error { _, err := svc.Run(provider, testManifest()); return err },
+			err:   ErrWrongState,
+			state: ReceivingAlgorithms,
+		},
+		{
+			desc:  "upload dataset before algorithms",
+			call:  func() error { _, err := svc.Data(provider, Artifact{Content: testDataset}); return err },
+			err:   ErrWrongState,
+			state: ReceivingAlgorithms,
+		},
+		{
+			desc:  "fetch result while receiving algorithms",
+			call:  func() error { _, err := svc.Result(consumer); return err },
+			err:   ErrWrongState,
+			state: ReceivingAlgorithms,
+		},
+		{
+			desc:  "upload algorithm",
+			call:  func() error { _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); return err },
+			state: ReceivingData,
+		},
+		{
+			desc:  "upload algorithm twice",
+			call:  func() error { _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); return err },
+			err:   ErrWrongState,
+			state: ReceivingData,
+		},
+	}
+
+	for _, step := range steps {
+		err := step.call()
+		if !errors.Is(err, step.err) || (step.err == nil && err != nil) {
+			t.Fatalf("%s: expected error %v got %v", step.desc, step.err, err)
+		}
+		status, _ := svc.Status(context.Background())
+		if status.State != step.state {
+			t.Fatalf("%s: expected state %s got %s", step.desc, step.state, status.State)
+		}
+	}
+
+	if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+	status := waitState(t, svc)
+	if status.State != Finished {
+		t.Fatalf("expected state %s got %s: %s", Finished, status.State, status.Error)
+	}
+	if status.StartTime.IsZero() || status.EndTime.Before(status.StartTime) {
+		t.Errorf("unexpected computation times %s and %s", status.StartTime, status.EndTime)
+	}
+
+	result, err := svc.Result(consumer)
+	if err != nil {
+		t.Fatalf("unexpected error fetching result: %s", err)
+	}
+	if string(result) != string(testResult) {
+		t.Errorf("expected result %q got %q", testResult, result)
+	}
+	if _, err := svc.Run(provider, testManifest()); !errors.Is(err, ErrWrongState) {
+		t.Errorf("expected error %v running a finished computation, got %v", ErrWrongState, err)
+	}
+}
+
+func TestLifecycleFailure(t *testing.T) {
+	errAlgorithm := errors.New("algorithm failed")
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return nil, errAlgorithm
+	}))
+	provider := asParty(testProvider)
+
+	if _, err := svc.Run(provider, testManifest()); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	if _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+	if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+
+	status := waitState(t, svc)
+	if status.State != Failed {
+		t.Fatalf("expected state %s got %s", Failed, status.State)
+	}
+	if status.Error == "" {
+		t.Error("expected the status to report the failure")
+	}
+	_, err := svc.Result(asParty(testConsumer))
+	if !errors.Is(err, ErrComputationFailed) || !errors.Is(err, errAlgorithm) {
+		t.Errorf("expected error %v wrapping %v got %v", ErrComputationFailed, errAlgorithm, err)
+	}
+}
+
+func TestInvalidTransition(t *testing.T) {
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+
+	cases := []struct {
+		desc string
+		next State
+		err  error
+	}{
+		{desc: "skip to running", next: Running, err: ErrWrongState},
+		{desc: "skip to finished", next: Finished, err: ErrWrongState},
+		{desc: "receive algorithms", next: ReceivingAlgorithms},
+		{desc: "go back", next: ReceivingManifest, err: ErrWrongState},
+	}
+
+	for _, tc := range cases {
+		err := svc.transition(tc.next)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+		if tc.err != nil && ErrorKind(err) != KindWrongState {
+			t.Errorf("%s: expected kind %s got %s", tc.desc, KindWrongState, ErrorKind(err))
+		}
+	}
+}
+
+func TestManifestDigests(t *testing.T) {
+	algorithm, dataset := digest(testAlgorithm), digest(testDataset)
+
+	cases := []struct {
+		desc       string
+		algorithms []string
+		datasets   []string
+		err        error
+	}{
+		{desc: "lower case digests", algorithms: []string{algorithm}, datasets: []string{dataset}},
+		{desc: "upper case digests", algorithms: []string{strings.ToUpper(algorithm)}, datasets: []string{strings.ToUpper(dataset)}},
+		{desc: "empty digest", algorithms: []string{""}, datasets: []string{dataset}, err: ErrMalformedEntity},
+		{desc: "non hex digest", algorithms: []string{"algo123"}, datasets: []string{dataset}, err: ErrMalformedEntity},
+		{desc: "short digest", algorithms: []string{algorithm[:32]}, datasets: []string{dataset}, err: ErrMalformedEntity},
+		{desc: "duplicate digest", algorithms: []string{algorithm}, datasets: []string{strings.ToUpper(algorithm)}, err: ErrMalformedEntity},
+	}
+
+	for _, tc := range cases {
+		svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+			return nil, nil
+		}))
+		cmp := testManifest()
+		cmp.Algorithms, cmp.Datasets = tc.algorithms, tc.datasets
+
+		_, err := svc.Run(asParty(testProvider), cmp)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestUploadDigests(t *testing.T) {
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return nil, nil
+	}))
+	provider := asParty(testProvider)
+	cmp := testManifest()
+	cmp.Algorithms = []string{strings.ToUpper(digest(testAlgorithm)), digest([]byte("second"))}
+	if _, err := svc.Run(provider, cmp); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+
+	cases := []struct {
+		desc    string
+		content []byte
+		id      string
+		err     error
+	}{
+		{desc: "undeclared algorithm", content: []byte("other"), err: ErrUndeclaredAlgorithm},
+		{desc: "tampered algorithm", content: append(append([]byte{}, testAlgorithm...), '!'), err: ErrUndeclaredAlgorithm},
+		{desc: "declared algorithm", content: testAlgorithm, id: digest(testAlgorithm)},
+		{desc: "declared algorithm twice", content: testAlgorithm, err: ErrAlreadyUploaded},
+	}
+
+	for _, tc := range cases {
+		id, err := svc.Algo(provider, Artifact{Content: tc.content})
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+		if id != tc.id {
+			t.Errorf("%s: expected ID %q got %q", tc.desc, tc.id, id)
+		}
+	}
+}
+
+func TestManifestDatasetProviders(t *testing.T) {
+	cases := []struct {
+		desc      string
+		providers []string
+		err       error
+	}{
+		{desc: "no providers", err: ErrMalformedEntity},
+		{desc: "provider of every dataset", providers: []string{"alice", "bob"}},
+		{desc: "fewer providers than datasets", providers: []string{"alice"}, err: ErrMalformedEntity},
+		{desc: "more providers than datasets", providers: []string{"alice", "bob", "carol"}, err: ErrMalformedEntity},
+	}
+
+	for _, tc := range cases {
+		cmp := testManifest()
+		cmp.Datasets = []string{digest([]byte("first")), digest([]byte("second"))}
+		cmp.DatasetProviders = tc.providers
+
+		err := validateManifest(cmp)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestSealedResult(t *testing.T) {
+	alice, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	bob, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+
+	cases := []struct {
+		desc string
+		keys []*ecdh.PrivateKey
+	}{
+		{desc: "fetch result without consumer keys"},
+		{desc: "fetch result sealed to one consumer", keys: []*ecdh.PrivateKey{alice}},
+		{desc: "fetch result sealed to two consumers", keys: []*ecdh.PrivateKey{alice, bob}},
+	}
+
+	for _, tc := range cases {
+		svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+			return append([]byte{}, testResult...), nil
+		}))
+		provider := asParty(testProvider)
+
+		cmp := testManifest()
+		for i, key := range tc.keys {
+			if i > 0 {
+				cmp.ResultConsumers = append(cmp.ResultConsumers, fmt.Sprintf("%s-%d", testConsumer, i))
+			}
+			cmp.ResultConsumerKeys = append(cmp.ResultConsumerKeys, hex.EncodeToString(key.PublicKey().Bytes()))
+		}
+		if _, err := svc.Run(provider, cmp); err != nil {
+			t.Fatalf("%s: unexpected error running manifest: %s", tc.desc, err)
+		}
+		if _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); err != nil {
+			t.Fatalf("%s: unexpected error uploading algorithm: %s", tc.desc, err)
+		}
+		if _, err := svc.Data(provider, Artifact{Content: testDataset}); err != nil {
+			t.Fatalf("%s: unexpected error uploading dataset: %s", tc.desc, err)
+		}
+		if status := waitState(t, svc); status.State != Finished {
+			t.Fatalf("%s: expected state %s got %s", tc.desc, Finished, status.State)
+		}
+
+		result, err := svc.Result(asParty(testConsumer))
+		if err != nil {
+			t.Fatalf("%s: unexpected error fetching result: %s", tc.desc, err)
+		}
+		if len(tc.keys) == 0 && !bytes.Equal(result, testResult) {
+			t.Errorf("%s: expected result %q got %q", tc.desc, testResult, result)
+		}
+		for i, key := range tc.keys {
+			opened, err := envelope.Open(result, key)
+			if err != nil || !bytes.Equal(opened, testResult) {
+				t.Errorf("%s: expected consumer %d to open result %q got %q (%v)", tc.desc, i, testResult, opened, err)
+			}
+		}
+	}
+}
+
+func TestManifestResultConsumerKeys(t *testing.T) {
+	cases := []struct {
+		desc string
+		keys []string
+		err  error
+	}{
+		{desc: "valid key", keys: []string{strings.Repeat("ab", envelope.KeySize)}},
+		{desc: "short key", keys: []string{strings.Repeat("ab", envelope.KeySize-1)}, err: ErrMalformedEntity},
+		{desc: "key which is not hex encoded", keys: []string{"key"}, err: ErrMalformedEntity},
+	}
+
+	for _, tc := range cases {
+		cmp := testManifest()
+		cmp.ResultConsumerKeys = tc.keys
+
+		err := validateManifest(cmp)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+// newFinishedTestService returns the service with the computation of the
+// manifest finished with testResult.
+func newFinishedTestService(t *testing.T, cmp Computation) *agentService {
+	t.Helper()
+
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	provider := asParty(testProvider)
+	if _, err := svc.Run(provider, cmp); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	// The inputs are wiped once the computation ends, so the service gets
+	// copies of the test artifacts.
+	if _, err := svc.Algo(provider, Artifact{Content: append([]byte{}, testAlgorithm...)}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+	if _, err := svc.Data(provider, Artifact{Content: append([]byte{}, testDataset...)}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+	if status := waitState(t, svc); status.State != Finished {
+		t.Fatalf("expected state %s got %s (%s)", Finished, status.State, status.Error)
+	}
+
+	return svc
+}
+
+func TestResultWipe(t *testing.T) {
+	cmp := testManifest()
+	cmp.ResultConsumers = []string{"alice", "bob"}
+	svc := newFinishedTestService(t, cmp)
+
+	steps := []struct {
+		desc     string
+		consumer string
+		err      error
+	}{
+		{desc: "fetch result as first consumer", consumer: "alice"},
+		{desc: "fetch result again as first consumer", consumer: "alice"},
+		{desc: "fetch result as last consumer", consumer: "bob"},
+		{desc: "fetch wiped result", consumer: "alice", err: ErrWiped},
+	}
+
+	var results [][]byte
+	for _, step := range steps {
+		result, err := svc.Result(asParty(step.consumer))
+		if !errors.Is(err, step.err) || (step.err == nil && err != nil) {
+			t.Fatalf("%s: expected error %v got %v", step.desc, step.err, err)
+		}
+		if err == nil {
+			results = append(results, result)
+		}
+	}
+
+	// The results fetched before the wipe are not zeroed by it.
+	for i, result := range results {
+		if !bytes.Equal(result, testResult) {
+			t.Errorf("expected result %d to be %q got %q", i, testResult, result)
+		}
+	}
+}
+
+func TestResultConcurrentWipe(t *testing.T) {
+	consumers := []string{"alice", "bob", "carol"}
+	cmp := testManifest()
+	cmp.ResultConsumers = consumers
+	svc := newFinishedTestService(t, cmp)
+
+	var wg sync.WaitGroup
+	results := make([][]byte, len(consumers))
+	errs := make([]error, len(consumers))
+	for i, consumer := range consumers {
+		wg.Add(1)
+		go func(i int, consumer string) {
+			defer wg.Done()
+			results[i], errs[i] = svc.Result(asParty(consumer))
+		}(i, consumer)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		svc.expire()
+	}()
+	wg.Wait()
+
+	for i, consumer := range consumers {
+		switch {
+		case errors.Is(errs[i], ErrWiped):
+		case errs[i] != nil:
+			t.Errorf("%s: unexpected error fetching result: %s", consumer, errs[i])
+		case !bytes.Equal(results[i], testResult):
+			t.Errorf("%s: expected result %q got %q", consumer, testResult, results[i])
+		}
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	measurement := [attestation.MeasurementSize]byte{1}
+	cert, err := attestation.NewAttestedCertificate(attestation.NewMockProvider(key, measurement, [attestation.HostDataSize]byte{}))
+	if err != nil {
+		t.Fatalf("unexpected error creating attested certificate: %s", err)
+	}
+	certs, err := attestation.NewMockCertificates(key)
+	if err != nil {
+		t.Fatalf("unexpected error creating mock certificates: %s", err)
+	}
+	policy := attestation.Policy{Measurements: []string{hex.EncodeToString(measurement[:])}, AllowSMT: true}
+
+	finished := newFinishedTestService(t, testManifest())
+	finished.signer = &cert
+	unsigned := newFinishedTestService(t, testManifest())
+	running := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	running.signer = &cert
+	if _, err := running.Run(asParty(testProvider), testManifest()); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+
+	cases := []struct {
+		desc string
+		svc  *agentService
+		ctx  context.Context
+		err  error
+	}{
+		{
+			desc: "fetch provenance as result consumer",
+			svc:  finished,
+			ctx:  asParty(testConsumer),
+		},
+		{
+			desc: "fetch provenance as undeclared consumer",
+			svc:  finished,
+			ctx:  asParty(testProvider),
+			err:  ErrUnauthorizedAccess,
+		},
+		{
+			desc: "fetch provenance before the computation finishes",
+			svc:  running,
+			ctx:  asParty(testConsumer),
+			err:  ErrWrongState,
+		},
+		{
+			desc: "fetch provenance without signing key",
+			svc:  unsigned,
+			ctx:  asParty(testConsumer),
+			err:  errNoProvenanceKey,
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := tc.svc.Provenance(tc.ctx)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if tc.err != nil {
+			continue
+		}
+		doc, err := provenance.Verify(data, certs, policy)
+		if err != nil {
+			t.Errorf("%s: unexpected error verifying provenance: %s", tc.desc, err)
+			continue
+		}
+		cmp := testManifest()
+		if doc.ComputationID != cmp.ID || !reflect.DeepEqual(doc.Algorithms, cmp.Algorithms) || !reflect.DeepEqual(doc.Datasets, cmp.Datasets) || doc.Runtime != testRuntime {
+			t.Errorf("%s: expected provenance of the computation %+v got %+v", tc.desc, cmp, doc)
+		}
+		if doc.ResultDigest != digest(testResult) {
+			t.Errorf("%s: expected result digest %s got %s", tc.desc, digest(testResult), doc.ResultDigest)
+		}
+	}
+}
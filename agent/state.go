@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+// State represents a stage in the lifecycle of the computation handled by
+// the agent.
+type State uint8
+
+const (
+	// ReceivingManifest is the initial state in which the agent waits for
+	// the computation manifest.
+	ReceivingManifest State = iota
+	// ReceivingAlgorithms indicates that the manifest is accepted and the
+	// agent waits for the declared algorithms.
+	ReceivingAlgorithms
+	// ReceivingData indicates that all algorithms are uploaded and the agent
+	// waits for the declared datasets.
+	ReceivingData
+	// Running indicates that all inputs are present and the computation is
+	// being executed.
+	Running
+	// Finished indicates that the computation completed and the result is
+	// available.
+	Finished
+	// Failed indicates that the computation terminated with an error.
+	Failed
+)
+
+var stateNames = map[State]string{
+	ReceivingManifest:   "ReceivingManifest",
+	ReceivingAlgorithms: "ReceivingAlgorithms",
+	ReceivingData:       "ReceivingData",
+	Running:             "Running",
+	Finished:            "Finished",
+	Failed:              "Failed",
+}
+
+// transitions lists the states reachable from each state.
+var transitions = map[State][]State{
+	ReceivingManifest:   {ReceivingAlgorithms},
+	ReceivingAlgorithms: {ReceivingData},
+	ReceivingData:       {Running},
+	Running:             {Finished, Failed},
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// canTransition reports whether the lifecycle permits moving from s to next.
+func (s State) canTransition(next State) bool {
+	for _, st := range transitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
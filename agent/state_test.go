@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import "testing"
+
+func TestStateCanTransition(t *testing.T) {
+	states := []State{ReceivingManifest, ReceivingAlgorithms, ReceivingData, Running, Finished, Failed}
+	allowed := map[[2]State]bool{
+		{ReceivingManifest, ReceivingAlgorithms}: true,
+		{ReceivingAlgorithms, ReceivingData}:     true,
+		{ReceivingData, Running}:                 true,
+		{Running, Finished}:                      true,
+		{Running, Failed}:                        true,
+	}
+
+	for _, from := range states {
+		for _, to := range states {
+			want := allowed[[2]State{from, to}]
+			if got := from.canTransition(to); got != want {
+				t.Errorf("%s -> %s: expected %t got %t", from, to, want, got)
+			}
+		}
+	}
+}
+
+func TestStateString(t *testing.T) {
+	cases := map[State]string{
+		ReceivingManifest:   "ReceivingManifest",
+		ReceivingAlgorithms: "ReceivingAlgorithms",
+		ReceivingData:       "ReceivingData",
+		Running:             "Running",
+		Finished:            "Finished",
+		Failed:              "Failed",
+		State(42):           "Unknown",
+	}
+
+	for state, want := range cases {
+		if got := state.String(); got != want {
+			t.Errorf("expected %q got %q", want, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+;; algorithm.wasm is the WASI algorithm run by the tests of the wasm runtime,
+;; assembled from this file with `wat2wasm algorithm.wat`.
+;;
+;; It takes the path of a single dataset and copies the dataset to
+;; /output/result. It exits with 3 if it can open the dataset for writing,
+;; which the read-only mount of the datasets must prevent, and with 42 after
+;; writing the result if the dataset starts with '!'. Other exit codes report
+;; failed WASI calls.
+(module
+  (import "wasi_snapshot_preview1" "args_sizes_get" (func $args_sizes_get (param i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "args_get" (func $args_get (param i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "path_open" (func $path_open (param i32 i32 i32 i32 i32 i64 i64 i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "fd_read" (func $fd_read (param i32 i32 i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "fd_write" (func $fd_write (param i32 i32 i32 i32) (result i32)))
+  (import "wasi_snapshot_preview1" "fd_close" (func $fd_close (param i32) (result i32)))
+  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit (param i32)))
+
+  ;; 0: argc, 4: size of the arguments, 16: argv, 128: arguments,
+  ;; 800: name of the result file, 900: iovec, 908: bytes read or written,
+  ;; 912: opened fd, 1024: content of the dataset.
+  (memory (export "memory") 1)
+  (data (i32.const 800) "result")
+
+  ;; check exits with code if errno reports a failure.
+  (func $check (param $errno i32) (param $code i32)
+    (if (local.get $errno)
+      (then (call $proc_exit (local.get $code)))))
+
+  (func (export "_start")
+    (local $path i32) (local $len i32) (local $fd i32) (local $n i32)
+
+    (call $check (call $args_sizes_get (i32.const 0) (i32.const 4)) (i32.const 2))
+    (if (i32.ne (i32.load (i32.const 0)) (i32.const 2))
+      (then (call $proc_exit (i32.const 2))))
+    (if (i32.gt_u (i32.load (i32.const 4)) (i32.const 512))
+      (then (call $proc_exit (i32.const 2))))
+    (call $check (call $args_get (i32.const 16) (i32.const 128)) (i32.const 2))
+
+    ;; The dataset is passed as /datasets/<digest>. The output directory is
+    ;; preopened at fd 3 and the datasets directory at fd 4, so the dataset
+    ;; is opened at <digest> relative to fd 4.
+    (local.set $path (i32.add (i32.load (i32.const 20)) (i32.const 10)))
+    (local.set $len
+      (i32.sub
+        (i32.sub (i32.add (i32.const 128) (i32.load (i32.const 4))) (local.get $path))
+        (i32.const 1)))
+
+    ;; Opening the dataset with the fd_write right must fail.
+    (if (i32.eqz (call $path_open (i32.const 4) (i32.const 0) (local.get $path) (local.get $len)
+          (i32.const 0) (i64.const 64) (i64.const 0) (i32.const 0) (i32.const 912)))
+      (then (call $proc_exit (i32.const 3))))
+
+    ;; Read the dataset with the fd_read right.
+    (call $check (call $path_open (i32.const 4) (i32.const 0) (local.get $path) (local.get $len)
+      (i32.const 0) (i64.const 2) (i64.const 0) (i32.const 0) (i32.const 912)) (i32.const 4))
+    (local.set $fd (i32.load (i32.const 912)))
+    (i32.store (i32.const 900) (i32.const 1024))
+    (i32.store (i32.const 904) (i32.const 4096))
+    (call $check (call $fd_read (local.get $fd) (i32.const 900) (i32.const 1) (i32.const 908)) (i32.const 5))
+    (local.set $n (i32.load (i32.const 908)))
+
+    ;; Create /output/result with O_CREAT | O_TRUNC and write the dataset.
+    (call $check (call $path_open (i32.const 3) (i32.const 0) (i32.const 800) (i32.const 6)
+      (i32.const 9) (i64.const 64) (i64.const 0) (i32.const 0) (i32.const 912)) (i32.const 6))
+    (local.set $fd (i32.load (i32.const 912)))
+    (i32.store (i32.const 904) (local.get $n))
+    (call $check (call $fd_write (local.get $fd) (i32.const 900) (i32.const 1) (i32.const 908)) (i32.const 7))
+    (call $check (call $fd_close (local.get $fd)) (i32.const 7))
+
+    (if (i32.and
+          (i32.gt_u (local.get $n) (i32.const 0))
+          (i32.eq (i32.load8_u (i32.const 1024)) (i32.const 33)))
+      (then (call $proc_exit (i32.const 42))))))
@@ -20,7 +20,7 @@ func New(svc agent.Service, tracer trace.Tracer) agent.Service {
 	return &tracingMiddleware{tracer, svc}
 }
 
-func (tm *tracingMiddleware) Run(ctx context.Context, cmp agent.Computation) (string, error) {
+func (tm *tracingMiddleware) Run(ctx context.Context, cmp agent.Computation) (agent.Computation, error) {
 	ctx, span := tm.tracer.Start(ctx, "run", trace.WithAttributes(
 		attribute.String("id", cmp.ID),
 		attribute.String("name", cmp.Name),
@@ -37,20 +37,71 @@ func (tm *tracingMiddleware) Run(ctx context.Context, cmp agent.Computation) (st
 	return tm.svc.Run(ctx, cmp)
 }
 
-func (tm *tracingMiddleware) Algo(ctx context.Context, algorithm []byte) (string, error) {
-	ctx, span := tm.tracer.Start(ctx, "algo")
+func (tm *tracingMiddleware) Algo(ctx context.Context, algorithm agent.Artifact) (string, error) {
+	ctx, span := tm.tracer.Start(ctx, "algo", trace.WithAttributes(
+		attribute.Bool("encrypted", algorithm.ID != ""),
+	))
 	defer span.End()
 
 	return tm.svc.Algo(ctx, algorithm)
 }
 
-func (tm *tracingMiddleware) Data(ctx context.Context, dataset []byte) (string, error) {
-	ctx, span := tm.tracer.Start(ctx, "data")
+func (tm *tracingMiddleware) Data(ctx context.Context, dataset agent.Artifact) (string, error) {
+	ctx, span := tm.tracer.Start(ctx, "data", trace.WithAttributes(
+		attribute.Bool("encrypted", dataset.ID != ""),
+	))
 	defer span.End()
 
 	return tm.svc.Data(ctx, dataset)
 }
 
+func (tm *tracingMiddleware) UploadAlgorithm(ctx context.Context, upload agent.Upload) (agent.UploadProgress, error) {
+	ctx, span := tm.tracer.Start(ctx, "upload_algorithm", trace.WithAttributes(
+		attribute.String("id", upload.ID),
+		attribute.Int64("size", upload.Size),
+		attribute.Int64("offset", upload.Offset),
+	))
+	defer span.End()
+
+	return tm.svc.UploadAlgorithm(ctx, upload)
+}
+
+func (tm *tracingMiddleware) UploadDataset(ctx context.Context, upload agent.Upload) (agent.UploadProgress, error) {
+	ctx, span := tm.tracer.Start(ctx, "upload_dataset", trace.WithAttributes(
+		attribute.String("id", upload.ID),
+		attribute.Int64("size", upload.Size),
+		attribute.Int64("offset", upload.Offset),
+	))
+	defer span.End()
+
+	return tm.svc.UploadDataset(ctx, upload)
+}
+
+func (tm *tracingMiddleware) UploadProgress(ctx context.Context, id string) (agent.UploadProgress, error) {
+	ctx, span := tm.tracer.Start(ctx, "upload_progress", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.UploadProgress(ctx, id)
+}
+
+func (tm *tracingMiddleware) ProvideKey(ctx context.Context, id string, key []byte) error {
+	ctx, span := tm.tracer.Start(ctx, "provide_key", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ProvideKey(ctx, id, key)
+}
+
+func (tm *tracingMiddleware) Provenance(ctx context.Context) ([]byte, error) {
+	ctx, span := tm.tracer.Start(ctx, "provenance")
+	defer span.End()
+
+	return tm.svc.Provenance(ctx)
+}
+
 func (tm *tracingMiddleware) Result(ctx context.Context) ([]byte, error) {
 	ctx, span := tm.tracer.Start(ctx, "result")
 	defer span.End()
@@ -58,9 +109,32 @@ func (tm *tracingMiddleware) Result(ctx context.Context) ([]byte, error) {
 	return tm.svc.Result(ctx)
 }
 
-func (tm *tracingMiddleware) Attestation(ctx context.Context) ([]byte, error) {
+func (tm *tracingMiddleware) Attestation(ctx context.Context, nonce []byte) ([]byte, error) {
 	ctx, span := tm.tracer.Start(ctx, "attestation")
 	defer span.End()
 
-	return tm.svc.Attestation(ctx)
-}
\ No newline at end of file
+	return tm.svc.Attestation(ctx, nonce)
+}
+
+func (tm *tracingMiddleware) Status(ctx context.Context) (agent.RunStatus, error) {
+	ctx, span := tm.tracer.Start(ctx, "status")
+	defer span.End()
+
+	return tm.svc.Status(ctx)
+}
+
+func (tm *tracingMiddleware) Logs(ctx context.Context, follow bool) (<-chan agent.LogEntry, error) {
+	ctx, span := tm.tracer.Start(ctx, "logs", trace.WithAttributes(
+		attribute.Bool("follow", follow),
+	))
+	defer span.End()
+
+	return tm.svc.Logs(ctx, follow)
+}
+
+func (tm *tracingMiddleware) Subscribe(ctx context.Context) (<-chan agent.Event, error) {
+	ctx, span := tm.tracer.Start(ctx, "subscribe")
+	defer span.End()
+
+	return tm.svc.Subscribe(ctx)
+}
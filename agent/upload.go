@@ -0,0 +1,409 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// diskTmpDir is used as the default base directory of the staging
+	// files of chunked uploads, since it is disk backed on most systems.
+	diskTmpDir      = "/var/tmp"
+	uploadDirName   = "cocos-upload-*"
+	uploadChunkSize = 64 << 10
+)
+
+// ErrUploadOffset indicates that a chunked upload does not resume at the
+// number of bytes received by the agent.
+var ErrUploadOffset = errors.New("upload offset does not match received data")
+
+// Upload is a chunked upload of an algorithm or dataset, which may resume
+// an interrupted upload of the same artifact.
+type Upload struct {
+	// ID is the digest of the artifact declared in the manifest.
+	ID string
+	// Size is the total size of the artifact in bytes.
+	Size int64
+	// Offset is the position in the artifact of the first byte read from
+	// Content. It must equal the number of bytes received so far.
+	Offset int64
+	// Content yields the artifact from Offset on. The upload is suspended
+	// when it returns an error other than io.EOF, and may be resumed later.
+	Content io.Reader
+}
+
+// UploadProgress reports how much of an artifact the agent has received.
+type UploadProgress struct {
+	ID       string
+	Size     int64
+	Received int64
+	Complete bool
+}
+
+// upload is a chunked upload, which is written to a staging file outside of
+// the memory of the agent and moved into the workspace before execution.
+type upload struct {
+	path string
+	size int64
+	// mu guards received, hash and stream, which the stream writing to the
+	// upload updates without holding the lock of the service.
+	mu       sync.Mutex
+	received int64
+	hash     hash.Hash
+	// stream identifies the stream writing to the upload, or is zero while
+	// none does, and streams counts the streams which wrote to it.
+	stream  uint64
+	streams uint64
+	// done is set once the whole artifact is received and matches its
+	// digest. It is guarded by the lock of the service.
+	done bool
+}
+
+// defaultUploadDir returns the base directory of the staging files of
+// chunked uploads, preferring a disk backed directory when it is available.
+func defaultUploadDir() string {
+	if fi, err := os.Stat(diskTmpDir); err == nil && fi.IsDir() {
+		return diskTmpDir
+	}
+	return os.TempDir()
+}
+
+func (as *agentService) UploadAlgorithm(ctx context.Context, up Upload) (UploadProgress, error) {
+	return as.upload(ctx, up, true)
+}
+
+func (as *agentService) UploadDataset(ctx context.Context, up Upload) (UploadProgress, error) {
+	return as.upload(ctx, up, false)
+}
+
+func (as *agentService) UploadProgress(ctx context.Context, id string) (UploadProgress, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	id = canonicalDigest(id)
+	if err := as.authorizeArtifact(ctx, id); err != nil {
+		return UploadProgress{}, err
+	}
+
+	if u, ok := as.uploads[id]; ok {
+		return u.progress(id), nil
+	}
+	content, ok := as.algorithms[id]
+	if !ok {
+		content, ok = as.datasets[id]
+	}
+	if ok {
+		size := int64(len(content))
+		return UploadProgress{ID: id, Size: size, Received: size, Complete: true}, nil
+	}
+
+	return UploadProgress{ID: id}, nil
+}
+
+// upload receives a chunk of an algorithm or dataset. The lock is released
+// while the content is written, so that long uploads do not block the other
+// operations. The upload is released as soon as ctx ends, even if reading
+// the content blocks, so that an interrupted upload can be resumed by
+// another stream.
+func (as *agentService) upload(ctx context.Context, up Upload, algorithm bool) (UploadProgress, error) {
+	up.ID = canonicalDigest(up.ID)
+
+	as.mu.Lock()
+	u, stream, err := as.beginUpload(ctx, up, algorithm)
+	as.mu.Unlock()
+	if err != nil {
+		return UploadProgress{}, err
+	}
+
+	written := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			u.release(stream)
+		case <-written:
+		}
+	}()
+	err = u.write(stream, up.Content)
+	close(written)
+	u.release(stream)
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	switch {
+	case err != nil:
+		return u.progress(up.ID), err
+	case u.done:
+		// Another stream resumed the upload after ctx ended and
+		// completed it.
+		return u.progress(up.ID), nil
+	case as.uploads[up.ID] != u:
+		return UploadProgress{}, fmt.Errorf("%w: upload of %s was discarded", ErrWrongState, up.ID)
+	}
+	if p := u.progress(up.ID); p.Received < p.Size {
+		return p, nil
+	}
+	if err := as.completeUpload(up.ID, u, algorithm); err != nil {
+		return UploadProgress{}, err
+	}
+
+	return u.progress(up.ID), nil
+}
+
+// beginUpload checks that the upload may proceed and returns the upload to
+// write to, creating it if the artifact is uploaded for the first time,
+// together with the identifier of the stream writing to it. It must be
+// called with as.mu held.
+func (as *agentService) beginUpload(ctx context.Context, up Upload, algorithm bool) (*upload, uint64, error) {
+	if algorithm {
+		if err := authorize(ctx, as.computation.AlgorithmProviders, "algorithm provider"); err != nil {
+			return nil, 0, err
+		}
+		if as.state != ReceivingAlgorithms {
+			return nil, 0, fmt.Errorf("%w: cannot upload algorithm while %s", ErrWrongState, as.state)
+		}
+		if !contains(as.computation.Algorithms, up.ID) {
+			return nil, 0, fmt.Errorf("%w: %s", ErrUndeclaredAlgorithm, up.ID)
+		}
+		if _, ok := as.algorithms[up.ID]; ok {
+			return nil, 0, fmt.Errorf("%w: %s", ErrAlreadyUploaded, up.ID)
+		}
+	} else {
+		if err := authorize(ctx, as.computation.DatasetProviders, "dataset provider"); err != nil {
+			return nil, 0, err
+		}
+		if as.state != ReceivingData {
+			return nil, 0, fmt.Errorf("%w: cannot upload dataset while %s", ErrWrongState, as.state)
+		}
+		if !contains(as.computation.Datasets, up.ID) {
+			return nil, 0, fmt.Errorf("%w: %s", ErrUndeclaredDataset, up.ID)
+		}
+		if _, ok := as.datasets[up.ID]; ok {
+			return nil, 0, fmt.Errorf("%w: %s", ErrAlreadyUploaded, up.ID)
+		}
+	}
+	if up.Size <= 0 {
+		return nil, 0, fmt.Errorf("%w: upload size must be positive", ErrMalformedEntity)
+	}
+
+	u, ok := as.uploads[up.ID]
+	if !ok {
+		if up.Offset != 0 {
+			return nil, 0, fmt.Errorf("%w: upload of %s starts at offset 0", ErrUploadOffset, up.ID)
+		}
+		var err error
+		if u, err = as.newUpload(up.ID, up.Size); err != nil {
+			return nil, 0, err
+		}
+		as.uploads[up.ID] = u
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	switch {
+	case u.stream != 0:
+		return nil, 0, fmt.Errorf("%w: upload of %s is in progress", ErrWrongState, up.ID)
+	case u.size != up.Size:
+		return nil, 0, fmt.Errorf("%w: size %d differs from size %d of the upload of %s", ErrMalformedEntity, up.Size, u.size, up.ID)
+	case u.received != up.Offset:
+		return nil, 0, fmt.Errorf("%w: upload of %s resumes at offset %d", ErrUploadOffset, up.ID, u.received)
+	}
+	u.streams++
+	u.stream = u.streams
+
+	return u, u.stream, nil
+}
+
+// newUpload creates the staging file of an upload. The staging directory is
+// created on disk by default, so that large artifacts do not fill the
+// memory of the agent, and staged artifacts are copied into the workspace
+// unless they are on the same file system. It must be called with as.mu
+// held.
+func (as *agentService) newUpload(id string, size int64) (*upload, error) {
+	if as.uploadDir == "" {
+		base := as.uploadBase
+		if base == "" {
+			base = defaultUploadDir()
+		}
+		dir, err := os.MkdirTemp(base, uploadDirName)
+		if err != nil {
+			return nil, fmt.Errorf("error creating upload directory: %w", err)
+		}
+		as.uploadDir = dir
+	}
+
+	path := filepath.Join(as.uploadDir, id)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerm)
+	if err != nil {
+		return nil, fmt.Errorf("error creating upload file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("error creating upload file: %w", err)
+	}
+
+	return &upload{
+		path: path,
+		size: size,
+		hash: sha256.New(),
+	}, nil
+}
+
+// completeUpload checks the digest of a fully received upload and registers
+// the artifact. It must be called with as.mu held.
+func (as *agentService) completeUpload(id string, u *upload, algorithm bool) error {
+	uploaded := as.datasets
+	if algorithm {
+		uploaded = as.algorithms
+	}
+	if _, ok := uploaded[id]; ok {
+		as.discardUpload(id, u)
+		return fmt.Errorf("%w: %s", ErrAlreadyUploaded, id)
+	}
+	if u.digest() != id {
+		as.discardUpload(id, u)
+		return fmt.Errorf("%w: uploaded content does not match digest %s", ErrMalformedEntity, id)
+	}
+
+	u.done = true
+	// The content stays in the staging file, which the workspace takes
+	// over when the computation starts.
+	uploaded[id] = nil
+	as.encrypted[id] = false
+	if algorithm {
+		as.publish(Event{Type: EventAlgorithmUploaded, ArtifactID: id})
+		if len(as.algorithms) == len(as.computation.Algorithms) {
+			return as.transition(ReceivingData)
+		}
+		return nil
+	}
+	as.publish(Event{Type: EventDatasetUploaded, ArtifactID: id})
+
+	return as.startIfReady()
+}
+
+// discardUpload wipes the staging file of an upload, which has to start over.
+// It must be called with as.mu held.
+func (as *agentService) discardUpload(id string, u *upload) {
+	overwrite(u.path)
+	_ = os.Remove(u.path)
+	delete(as.uploads, id)
+}
+
+// stagedFiles returns the staging files of the completed uploads indexed by
+// the artifact ID.
+func (as *agentService) stagedFiles() map[string]string {
+	files := make(map[string]string)
+	for id, u := range as.uploads {
+		if u.done {
+			files[id] = u.path
+		}
+	}
+
+	return files
+}
+
+// authorizeArtifact checks that the caller is a provider of the declared
+// algorithm or dataset with the given ID.
+func (as *agentService) authorizeArtifact(ctx context.Context, id string) error {
+	switch {
+	case contains(as.computation.Algorithms, id):
+		return authorize(ctx, as.computation.AlgorithmProviders, "algorithm provider")
+	case contains(as.computation.Datasets, id):
+		return authorize(ctx, as.computation.DatasetProviders, "dataset provider")
+	default:
+		return fmt.Errorf("%w: undeclared artifact %s", ErrNotFound, id)
+	}
+}
+
+// write appends the content to the staging file until the upload is
+// complete or the stream is released. Only the bytes written to the file are
+// accounted for, so that an interrupted upload resumes right after them.
+func (u *upload) write(stream uint64, content io.Reader) error {
+	f, err := os.OpenFile(u.path, os.O_WRONLY|os.O_APPEND, filePerm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			if werr := u.append(stream, f, buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// append writes a chunk read from the given stream to the staging file,
+// unless the stream was released in the meantime.
+func (u *upload) append(stream uint64, f *os.File, chunk []byte) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stream != stream {
+		return fmt.Errorf("%w: upload stream ended", ErrWrongState)
+	}
+	if u.received+int64(len(chunk)) > u.size {
+		return fmt.Errorf("%w: content exceeds the size of %d bytes", ErrMalformedEntity, u.size)
+	}
+	if _, err := f.Write(chunk); err != nil {
+		// Drop a partially written chunk so that the file matches the
+		// received count.
+		_ = f.Truncate(u.received)
+		return err
+	}
+	u.hash.Write(chunk)
+	u.received += int64(len(chunk))
+
+	return nil
+}
+
+// release ends the writing of the given stream to the upload, if it still
+// writes to it.
+func (u *upload) release(stream uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stream == stream {
+		u.stream = 0
+	}
+}
+
+// digest returns the hex encoded SHA-256 digest of the received content.
+func (u *upload) digest() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return hex.EncodeToString(u.hash.Sum(nil))
+}
+
+func (u *upload) progress(id string) UploadProgress {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return UploadProgress{
+		ID:       id,
+		Size:     u.size,
+		Received: u.received,
+		Complete: u.done,
+	}
+}
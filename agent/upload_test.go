@@ -0,0 +1,277 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	// testUpload spans several chunks of the staging writes.
+	testUpload   = bytes.Repeat([]byte("upload"), uploadChunkSize/2)
+	errInterrupt = errors.New("stream interrupted")
+)
+
+// interruptedReader yields its content and then fails like a broken
+// stream.
+type interruptedReader struct {
+	content io.Reader
+}
+
+func (ir interruptedReader) Read(p []byte) (int, error) {
+	n, err := ir.content.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errInterrupt
+	}
+	return n, err
+}
+
+// blockingReader yields its content once unblock is closed, like a stream
+// whose client stopped sending. It closes started when first read.
+type blockingReader struct {
+	started chan<- struct{}
+	unblock <-chan struct{}
+	content io.Reader
+	once    *sync.Once
+}
+
+func (br blockingReader) Read(p []byte) (int, error) {
+	br.once.Do(func() { close(br.started) })
+	<-br.unblock
+	return br.content.Read(p)
+}
+
+// newUploadService returns a service waiting for the upload of testUpload
+// as its dataset.
+func newUploadService(t *testing.T) *agentService {
+	t.Helper()
+
+	svc := newTestService(t, testRuntimeFunc(func(ctx context.Context, task Task) ([]byte, error) {
+		return append([]byte{}, testResult...), nil
+	}))
+	cmp := testManifest()
+	cmp.Datasets = []string{digest(testUpload)}
+	provider := asParty(testProvider)
+	if _, err := svc.Run(provider, cmp); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	if _, err := svc.Algo(provider, Artifact{Content: testAlgorithm}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+
+	return svc
+}
+
+func TestUpload(t *testing.T) {
+	size := int64(len(testUpload))
+	half := size / 2
+	corrupted := append([]byte{}, testUpload...)
+	corrupted[0] ^= 0xff
+
+	cases := []struct {
+		desc     string
+		uploads  []Upload
+		err      error
+		received int64
+		complete bool
+	}{
+		{
+			desc:     "upload in one stream",
+			uploads:  []Upload{{Size: size, Content: bytes.NewReader(testUpload)}},
+			received: size,
+			complete: true,
+		},
+		{
+			desc: "upload in two streams",
+			uploads: []Upload{
+				{Size: size, Content: bytes.NewReader(testUpload[:half])},
+				{Size: size, Offset: half, Content: bytes.NewReader(testUpload[half:])},
+			},
+			received: size,
+			complete: true,
+		},
+		{
+			desc:    "start upload at non-zero offset",
+			uploads: []Upload{{Size: size, Offset: half, Content: bytes.NewReader(testUpload[half:])}},
+			err:     ErrUploadOffset,
+		},
+		{
+			desc: "resume upload at wrong offset",
+			uploads: []Upload{
+				{Size: size, Content: bytes.NewReader(testUpload[:half])},
+				{Size: size, Offset: half + 1, Content: bytes.NewReader(testUpload[half+1:])},
+			},
+			err:      ErrUploadOffset,
+			received: half,
+		},
+		{
+			desc: "resume upload with other size",
+			uploads: []Upload{
+				{Size: size, Content: bytes.NewReader(testUpload[:half])},
+				{Size: size + 1, Offset: half, Content: bytes.NewReader(testUpload[half:])},
+			},
+			err:      ErrMalformedEntity,
+			received: half,
+		},
+		{
+			desc:    "upload without size",
+			uploads: []Upload{{Content: bytes.NewReader(testUpload)}},
+			err:     ErrMalformedEntity,
+		},
+		{
+			desc:     "upload more than size",
+			uploads:  []Upload{{Size: half, Content: bytes.NewReader(testUpload)}},
+			err:      ErrMalformedEntity,
+			received: uploadChunkSize,
+		},
+		{
+			desc:    "upload content not matching digest",
+			uploads: []Upload{{Size: size, Content: bytes.NewReader(corrupted)}},
+			err:     ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svc := newUploadService(t)
+		provider := asParty(testProvider)
+		id := digest(testUpload)
+
+		var err error
+		for _, up := range tc.uploads {
+			up.ID = id
+			if _, err = svc.UploadDataset(provider, up); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+
+		progress, err := svc.UploadProgress(provider, id)
+		if err != nil {
+			t.Fatalf("%s: unexpected progress error: %s", tc.desc, err)
+		}
+		if progress.Received != tc.received || progress.Complete != tc.complete {
+			t.Errorf("%s: expected %d bytes received and complete %t got %d and %t", tc.desc, tc.received, tc.complete, progress.Received, progress.Complete)
+		}
+		if tc.complete {
+			if status := waitState(t, svc); status.State != Finished {
+				t.Errorf("%s: expected state %s got %s: %s", tc.desc, Finished, status.State, status.Error)
+			}
+		}
+	}
+}
+
+func TestUploadResume(t *testing.T) {
+	svc := newUploadService(t)
+	provider := asParty(testProvider)
+	id := digest(testUpload)
+	size := int64(len(testUpload))
+	part := uploadChunkSize + 1
+
+	_, err := svc.UploadDataset(provider, Upload{
+		ID:      id,
+		Size:    size,
+		Content: interruptedReader{content: bytes.NewReader(testUpload[:part])},
+	})
+	if !errors.Is(err, errInterrupt) {
+		t.Fatalf("expected error %v got %v", errInterrupt, err)
+	}
+
+	progress, err := svc.UploadProgress(provider, id)
+	if err != nil {
+		t.Fatalf("unexpected progress error: %s", err)
+	}
+	if progress.Received != int64(part) || progress.Complete {
+		t.Fatalf("expected %d bytes received of incomplete upload got %+v", part, progress)
+	}
+
+	progress, err = svc.UploadDataset(provider, Upload{
+		ID:      id,
+		Size:    size,
+		Offset:  progress.Received,
+		Content: bytes.NewReader(testUpload[progress.Received:]),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error resuming upload: %s", err)
+	}
+	if progress.Received != size || !progress.Complete {
+		t.Errorf("expected complete upload of %d bytes got %+v", size, progress)
+	}
+	if status := waitState(t, svc); status.State != Finished {
+		t.Errorf("expected state %s got %s: %s", Finished, status.State, status.Error)
+	}
+}
+
+func TestUploadEndedStream(t *testing.T) {
+	svc := newUploadService(t)
+	provider := asParty(testProvider)
+	id := digest(testUpload)
+	size := int64(len(testUpload))
+
+	// The first stream blocks before sending any content, and ends.
+	ctx, cancel := context.WithCancel(provider)
+	started, unblock := make(chan struct{}), make(chan struct{})
+	errs := make(chan error)
+	go func() {
+		_, err := svc.UploadDataset(ctx, Upload{
+			ID:   id,
+			Size: size,
+			Content: blockingReader{
+				started: started,
+				unblock: unblock,
+				content: bytes.NewReader(testUpload),
+				once:    &sync.Once{},
+			},
+		})
+		errs <- err
+	}()
+	<-started
+
+	upload := func() (UploadProgress, error) {
+		return svc.UploadDataset(provider, Upload{ID: id, Size: size, Content: bytes.NewReader(testUpload)})
+	}
+	if _, err := upload(); !errors.Is(err, ErrWrongState) {
+		t.Fatalf("expected error %v while the first stream writes got %v", ErrWrongState, err)
+	}
+
+	// Once the first stream ends, another stream takes the upload over
+	// although the first one is still blocked.
+	cancel()
+	var (
+		progress UploadProgress
+		err      error
+	)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if progress, err = upload(); !errors.Is(err, ErrWrongState) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("upload was not released after the stream ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error resuming upload: %s", err)
+	}
+	if !progress.Complete {
+		t.Errorf("expected complete upload got %+v", progress)
+	}
+
+	// The content sent late by the first stream is dropped.
+	close(unblock)
+	if err := <-errs; !errors.Is(err, ErrWrongState) {
+		t.Errorf("expected error %v from the ended stream got %v", ErrWrongState, err)
+	}
+	if status := waitState(t, svc); status.State != Finished {
+		t.Errorf("expected state %s got %s: %s", Finished, status.State, status.Error)
+	}
+}
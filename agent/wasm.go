@@ -0,0 +1,170 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+const (
+	// RuntimeWasm executes algorithms as WASI modules in an embedded
+	// WebAssembly runtime.
+	RuntimeWasm = "wasm"
+
+	// WasmOutputDir is the directory of the module's virtual filesystem
+	// into which the algorithm writes its result.
+	WasmOutputDir = "/output"
+	// WasmResultFile is the file in WasmOutputDir from which the result of
+	// the algorithm is read.
+	WasmResultFile = "result"
+
+	wasmProgramName = "algorithm"
+	wasmPageSize    = 64 << 10
+	wasmMaxPages    = 1 << 16
+	wasmOutputName  = "output-*"
+)
+
+var errEscapesWorkspace = errors.New("input is outside of the workspace")
+
+type wasmRuntime struct{}
+
+var _ Runtime = (*wasmRuntime)(nil)
+
+// NewWasmRuntime returns a runtime executing algorithms as WASI modules.
+// The directories holding the inputs are mounted read-only at the same
+// paths relative to the root of the module's filesystem, the inputs are
+// passed as arguments and the result is read from WasmResultFile in the
+// writable WasmOutputDir once the module exits.
+func NewWasmRuntime() Runtime {
+	return &wasmRuntime{}
+}
+
+func (wr *wasmRuntime) Run(ctx context.Context, task Task) ([]byte, error) {
+	code, err := os.ReadFile(task.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("error reading wasm algorithm: %w", err)
+	}
+
+	rtConfig := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if task.Limits != nil && task.Limits.Memory > 0 {
+		pages := task.Limits.Memory / wasmPageSize
+		if pages < 1 {
+			pages = 1
+		}
+		if pages < wasmMaxPages {
+			rtConfig = rtConfig.WithMemoryLimitPages(uint32(pages))
+		}
+	}
+	rt := wazero.NewRuntimeWithConfig(ctx, rtConfig)
+	defer rt.Close(ctx)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		return nil, fmt.Errorf("error instantiating WASI: %w", err)
+	}
+
+	module, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling wasm algorithm: %w", err)
+	}
+
+	outputDir, err := os.MkdirTemp(task.Dir, wasmOutputName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating wasm output directory: %w", err)
+	}
+	defer os.RemoveAll(outputDir)
+
+	fsConfig := wazero.NewFSConfig().WithDirMount(outputDir, WasmOutputDir)
+	args := []string{wasmProgramName}
+	mounted := map[string]bool{}
+	for _, input := range task.Inputs {
+		guestPath, err := guestPath(task.Dir, input)
+		if err != nil {
+			return nil, err
+		}
+		hostDir := filepath.Dir(input)
+		if !mounted[hostDir] {
+			fsConfig = fsConfig.WithReadOnlyDirMount(hostDir, path.Dir(guestPath))
+			mounted[hostDir] = true
+		}
+		args = append(args, guestPath)
+	}
+
+	config := wazero.NewModuleConfig().
+		WithName(wasmProgramName).
+		WithArgs(args...).
+		WithFSConfig(fsConfig).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	if task.Stdout != nil {
+		config = config.WithStdout(task.Stdout)
+	}
+	if task.Stderr != nil {
+		config = config.WithStderr(task.Stderr)
+	}
+
+	if _, err := rt.InstantiateModule(ctx, module, config); err != nil {
+		var exitErr *sys.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%s algorithm execution error: %w", RuntimeWasm, err)
+		}
+		if exitErr.ExitCode() != 0 {
+			return nil, fmt.Errorf("%s algorithm execution error: %w", RuntimeWasm, wasmExitError{exitErr})
+		}
+	}
+
+	resultFile := filepath.Join(outputDir, WasmResultFile)
+	if fi, err := os.Stat(resultFile); err == nil && task.MaxResultSize > 0 && fi.Size() > task.MaxResultSize {
+		return nil, resultTooLarge(task)
+	}
+	result, err := os.ReadFile(resultFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNoResult
+		}
+		return nil, fmt.Errorf("error reading wasm algorithm result: %w", err)
+	}
+
+	return result, nil
+}
+
+// wasmExitError reports the exit code of a module as an int, like the
+// exec.ExitError of the process runtimes, where wazero reports a uint32.
+type wasmExitError struct {
+	err *sys.ExitError
+}
+
+func (e wasmExitError) Error() string {
+	return e.err.Error()
+}
+
+func (e wasmExitError) Unwrap() error {
+	return e.err
+}
+
+func (e wasmExitError) ExitCode() int {
+	return int(e.err.ExitCode())
+}
+
+// guestPath maps a host path inside the workspace to the path under which
+// it is visible to the module.
+func guestPath(dir, hostPath string) (string, error) {
+	rel, err := filepath.Rel(dir, hostPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%w: %s", errEscapesWorkspace, hostPath)
+	}
+
+	return "/" + filepath.ToSlash(rel), nil
+}
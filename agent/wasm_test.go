@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"testing"
+)
+
+// wasmAlgorithm copies its dataset to the result, fails with exit code 3 if
+// the dataset is writable and with exit code 42 if the dataset starts with
+// '!'. Its source is testdata/algorithm.wat.
+//
+//go:embed testdata/algorithm.wasm
+var wasmAlgorithm []byte
+
+const wasmFailExitCode = 42
+
+func TestWasmRuntime(t *testing.T) {
+	cases := []struct {
+		desc     string
+		dataset  []byte
+		result   []byte
+		exitCode int
+	}{
+		{
+			desc:    "run algorithm",
+			dataset: []byte("dataset"),
+			result:  []byte("dataset"),
+		},
+		{
+			desc:     "run failing algorithm",
+			dataset:  []byte("!dataset"),
+			exitCode: wasmFailExitCode,
+		},
+	}
+
+	for _, tc := range cases {
+		cmp := Computation{
+			Algorithms:       []string{digest(wasmAlgorithm)},
+			Datasets:         []string{digest(tc.dataset)},
+			DatasetProviders: []string{testProvider},
+		}
+		ws, err := newWorkspace(t.TempDir(), cmp,
+			map[string][]byte{cmp.Algorithms[0]: wasmAlgorithm},
+			map[string][]byte{cmp.Datasets[0]: tc.dataset}, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error creating workspace: %s", tc.desc, err)
+		}
+		task := Task{
+			Dir:       ws.dir,
+			Algorithm: ws.algorithms[0],
+			Inputs:    ws.datasets,
+		}
+
+		result, err := NewWasmRuntime().Run(context.Background(), task)
+		var exitErr interface{ ExitCode() int }
+		switch {
+		case tc.exitCode == 0 && err != nil:
+			t.Errorf("%s: unexpected error: %s", tc.desc, err)
+		case tc.exitCode != 0 && !errors.As(err, &exitErr):
+			t.Errorf("%s: expected an error with exit code %d got %v", tc.desc, tc.exitCode, err)
+		case tc.exitCode != 0 && exitErr.ExitCode() != tc.exitCode:
+			t.Errorf("%s: expected exit code %d got %d", tc.desc, tc.exitCode, exitErr.ExitCode())
+		}
+		if !bytes.Equal(result, tc.result) {
+			t.Errorf("%s: expected result %q got %q", tc.desc, tc.result, result)
+		}
+	}
+}
+
+func TestWasmExitCode(t *testing.T) {
+	svc := newTestService(t, nil)
+	provider := asParty(testProvider)
+	dataset := []byte("!dataset")
+
+	cmp := testManifest()
+	cmp.Runtime = RuntimeWasm
+	cmp.Algorithms = []string{digest(wasmAlgorithm)}
+	cmp.Datasets = []string{digest(dataset)}
+	if _, err := svc.Run(provider, cmp); err != nil {
+		t.Fatalf("unexpected error running manifest: %s", err)
+	}
+	if _, err := svc.Algo(provider, Artifact{Content: wasmAlgorithm}); err != nil {
+		t.Fatalf("unexpected error uploading algorithm: %s", err)
+	}
+	if _, err := svc.Data(provider, Artifact{Content: dataset}); err != nil {
+		t.Fatalf("unexpected error uploading dataset: %s", err)
+	}
+
+	status := waitState(t, svc)
+	if status.State != Failed {
+		t.Fatalf("expected state %s got %s", Failed, status.State)
+	}
+	if status.ExitCode != wasmFailExitCode {
+		t.Errorf("expected exit code %d got %d", wasmFailExitCode, status.ExitCode)
+	}
+}
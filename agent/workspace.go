@@ -0,0 +1,205 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	// tmpfsDir is used as the default base directory for workspaces so that
+	// computation inputs are kept in memory rather than written to disk.
+	tmpfsDir      = "/dev/shm"
+	workspaceName = "cocos-*"
+	algorithmsDir = "algorithms"
+	datasetsDir   = "datasets"
+	resultsDir    = "results"
+	manifestFile  = "datasets.json"
+	socketFile    = "unix_socket"
+	filePerm      = 0o600
+	dirPerm       = 0o700
+	wipeBlockSize = 64 << 10
+)
+
+// workspace is the private per-computation directory holding the files
+// passed to the algorithms during execution.
+type workspace struct {
+	dir        string
+	algorithms []string
+	datasets   []string
+	socket     string
+}
+
+// datasetEntry describes a dataset file in the workspace manifest.
+type datasetEntry struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider,omitempty"`
+	Path     string `json:"path"`
+}
+
+// defaultWorkDir returns the base directory for workspaces, preferring tmpfs
+// when it is available.
+func defaultWorkDir() string {
+	if fi, err := os.Stat(tmpfsDir); err == nil && fi.IsDir() {
+		return tmpfsDir
+	}
+	return os.TempDir()
+}
+
+// newWorkspace creates a workspace under base and writes the declared
+// algorithms and datasets into it, together with a manifest naming every
+// dataset by its ID and provider. Artifacts received through chunked uploads
+// are moved from their staging files in staged instead.
+func newWorkspace(base string, cmp Computation, algorithms, datasets map[string][]byte, staged map[string]string) (*workspace, error) {
+	if base == "" {
+		base = defaultWorkDir()
+	}
+
+	// MkdirTemp creates the directory with 0700 permissions.
+	dir, err := os.MkdirTemp(base, workspaceName)
+	if err != nil {
+		return nil, fmt.Errorf("error creating workspace: %w", err)
+	}
+
+	ws := &workspace{
+		dir:    dir,
+		socket: filepath.Join(dir, socketFile),
+	}
+	if err := ws.populate(cmp, algorithms, datasets, staged); err != nil {
+		_ = ws.remove()
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func (ws *workspace) populate(cmp Computation, algorithms, datasets map[string][]byte, staged map[string]string) error {
+	for _, sub := range []string{algorithmsDir, datasetsDir, resultsDir} {
+		if err := os.Mkdir(filepath.Join(ws.dir, sub), dirPerm); err != nil {
+			return fmt.Errorf("error creating workspace: %w", err)
+		}
+	}
+
+	for _, id := range cmp.Algorithms {
+		path := filepath.Join(ws.dir, algorithmsDir, id)
+		if err := writeArtifact(path, algorithms[id], staged[id]); err != nil {
+			return fmt.Errorf("error writing algorithm %s: %w", id, err)
+		}
+		ws.algorithms = append(ws.algorithms, path)
+	}
+
+	var manifest []datasetEntry
+	for i, id := range cmp.Datasets {
+		path := filepath.Join(ws.dir, datasetsDir, id)
+		if err := writeArtifact(path, datasets[id], staged[id]); err != nil {
+			return fmt.Errorf("error writing dataset %s: %w", id, err)
+		}
+		ws.datasets = append(ws.datasets, path)
+
+		// The manifest declares the provider of every dataset at the
+		// position of the dataset.
+		manifest = append(manifest, datasetEntry{ID: id, Provider: cmp.DatasetProviders[i], Path: path})
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("error encoding dataset manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.dir, manifestFile), manifestJSON, filePerm); err != nil {
+		return fmt.Errorf("error writing dataset manifest: %w", err)
+	}
+
+	return nil
+}
+
+// writeArtifact writes the content of an artifact to path or, if the
+// artifact was staged by a chunked upload, moves its staging file there.
+func writeArtifact(path string, content []byte, staged string) error {
+	if staged == "" {
+		return os.WriteFile(path, content, filePerm)
+	}
+	if err := os.Rename(staged, path); err == nil {
+		return nil
+	}
+
+	// The staging file is on another filesystem.
+	src, err := os.Open(staged)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
+
+// saveResult stores the result of the algorithm at the given position so
+// that it can be passed to the next algorithm in the chain.
+func (ws *workspace) saveResult(index int, result []byte) (string, error) {
+	path := filepath.Join(ws.dir, resultsDir, strconv.Itoa(index))
+	if err := os.WriteFile(path, result, filePerm); err != nil {
+		return "", fmt.Errorf("error writing intermediate result: %w", err)
+	}
+
+	return path, nil
+}
+
+// remove deletes the workspace with all of its content.
+func (ws *workspace) remove() error {
+	return removeDir(ws.dir)
+}
+
+// removeDir overwrites the files in dir with zeros, so that their content
+// does not outlive the computation in memory or on disk, and deletes dir
+// with all of its content.
+func removeDir(dir string) error {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			overwrite(path)
+		}
+		return nil
+	})
+
+	return os.RemoveAll(dir)
+}
+
+// overwrite replaces the content of the file at path with zeros. Failures
+// are ignored, as the file is deleted afterwards anyway.
+func overwrite(path string) {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return
+	}
+	zeros := make([]byte, wipeBlockSize)
+	for remaining := info.Size(); remaining > 0; {
+		n := int64(len(zeros))
+		if remaining < n {
+			n = remaining
+		}
+		if _, err := f.Write(zeros[:n]); err != nil {
+			return
+		}
+		remaining -= n
+	}
+	_ = f.Sync()
+}
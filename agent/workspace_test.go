@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceDatasetManifest(t *testing.T) {
+	first, second := []byte("first"), []byte("second")
+	datasets := map[string][]byte{digest(first): first, digest(second): second}
+
+	cases := []struct {
+		desc      string
+		providers []string
+	}{
+		{desc: "with distinct providers", providers: []string{"alice", "bob"}},
+		{desc: "with the same provider", providers: []string{"alice", "alice"}},
+	}
+
+	for _, tc := range cases {
+		cmp := Computation{
+			Algorithms:       []string{digest(testAlgorithm)},
+			Datasets:         []string{digest(first), digest(second)},
+			DatasetProviders: tc.providers,
+		}
+		algorithms := map[string][]byte{digest(testAlgorithm): testAlgorithm}
+		ws, err := newWorkspace(t.TempDir(), cmp, algorithms, datasets, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error creating workspace: %s", tc.desc, err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(ws.dir, manifestFile))
+		if err != nil {
+			t.Fatalf("%s: unexpected error reading dataset manifest: %s", tc.desc, err)
+		}
+		var entries []datasetEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			t.Fatalf("%s: unexpected error decoding dataset manifest: %s", tc.desc, err)
+		}
+		if len(entries) != len(cmp.Datasets) {
+			t.Fatalf("%s: expected %d datasets got %d", tc.desc, len(cmp.Datasets), len(entries))
+		}
+		for i, entry := range entries {
+			if entry.ID != cmp.Datasets[i] || entry.Provider != tc.providers[i] || entry.Path != ws.datasets[i] {
+				t.Errorf("%s: unexpected entry %d: %+v", tc.desc, i, entry)
+			}
+			content, err := os.ReadFile(entry.Path)
+			if err != nil || string(content) != string(datasets[entry.ID]) {
+				t.Errorf("%s: unexpected content of dataset %d: %q (%v)", tc.desc, i, content, err)
+			}
+		}
+
+		if err := ws.remove(); err != nil {
+			t.Errorf("%s: unexpected error removing workspace: %s", tc.desc, err)
+		}
+		if _, err := os.Stat(ws.dir); !os.IsNotExist(err) {
+			t.Errorf("%s: expected workspace to be removed, got %v", tc.desc, err)
+		}
+	}
+}
@@ -9,8 +9,9 @@ import (
 )
 
 func NewAlgorithmsCmd(sdk agentsdk.SDK) *cobra.Command {
+	var keyFile string
 
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "algo",
 		Short: "Upload an algorithm binary",
 		Args:  cobra.ExactArgs(1),
@@ -19,19 +20,39 @@ func NewAlgorithmsCmd(sdk agentsdk.SDK) *cobra.Command {
 
 			log.Println("Uploading algorithm binary:", algorithmFile)
 
-			algorithm, err := os.ReadFile(algorithmFile)
-			if err != nil {
-				log.Println("Error reading dataset file:", err)
-				return
-			}
-
-			response, err := sdk.UploadAlgorithm(algorithm)
-			if err != nil {
-				log.Println("Error uploading algorithm:", err)
-				return
+			var response string
+			if keyFile != "" {
+				// Encrypted artifacts are sealed as a whole, so they are
+				// read into memory and uploaded in a single message.
+				algorithm, err := os.ReadFile(algorithmFile)
+				if err != nil {
+					log.Println("Error reading algorithm file:", err)
+					return
+				}
+				key, err := os.ReadFile(keyFile)
+				if err != nil {
+					log.Println("Error reading key file:", err)
+					return
+				}
+				response, err = sdk.UploadEncryptedAlgorithm(algorithm, key)
+				if err != nil {
+					log.Println("Error uploading algorithm:", err)
+					return
+				}
+			} else {
+				var err error
+				response, err = sdk.UploadAlgorithmFile(algorithmFile)
+				if err != nil {
+					log.Println("Error uploading algorithm:", err)
+					return
+				}
 			}
 
 			log.Println("Succesfully uploaded algorithm:", response)
 		},
 	}
+
+	cmd.Flags().StringVar(&keyFile, "key", "", "File holding a 32 byte key encrypting the algorithm before upload")
+
+	return cmd
 }
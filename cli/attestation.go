@@ -1,24 +1,43 @@
 package cli
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"log"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/ultravioletrs/agent/pkg/attestation"
 	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
 )
 
-const attestationFilePath = "attestation.txt"
+const attestationFilePath = "attestation.bin"
 
 func NewAttestationCmd(sdk agentsdk.SDK) *cobra.Command {
+	var nonceHex string
 
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "attestation",
 		Short: "Retrieve attestation information",
 		Run: func(cmd *cobra.Command, args []string) {
 			log.Println("Checking attestation")
 
-			result, err := sdk.Attestation()
+			nonce := make([]byte, attestation.NonceSize)
+			if nonceHex != "" {
+				var err error
+				if nonce, err = hex.DecodeString(nonceHex); err != nil {
+					log.Println("Error decoding nonce:", err)
+					return
+				}
+			} else if _, err := rand.Read(nonce); err != nil {
+				log.Println("Error generating nonce:", err)
+				return
+			}
+
+			result, err := sdk.Attestation(nonce)
 			if err != nil {
 				log.Println("Error retrieving attestation:", err)
 				return
@@ -30,7 +49,107 @@ func NewAttestationCmd(sdk agentsdk.SDK) *cobra.Command {
 				return
 			}
 
-			log.Println("Attestation result retrieved and saved successfully!")
+			log.Printf("Attestation report for nonce %x retrieved and saved successfully!", nonce)
+		},
+	}
+
+	cmd.Flags().StringVar(&nonceHex, "nonce", "", "Hex encoded 32 byte nonce; a random one is used when omitted")
+	cmd.AddCommand(newAttestationVerifyCmd())
+
+	return cmd
+}
+
+func newAttestationVerifyCmd() *cobra.Command {
+	var (
+		nonceHex   string
+		certFile   string
+		policyFile string
+		arkFile    string
+		askFile    string
+		vcekFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify [report]",
+		Short: "Verify an attestation report offline",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			reportFile := attestationFilePath
+			if len(args) == 1 {
+				reportFile = args[0]
+			}
+
+			raw, err := os.ReadFile(reportFile)
+			if err != nil {
+				log.Println("Error reading attestation report:", err)
+				return
+			}
+			nonce, err := hex.DecodeString(nonceHex)
+			if err != nil {
+				log.Println("Error decoding nonce:", err)
+				return
+			}
+			var publicKey []byte
+			if certFile != "" {
+				if publicKey, err = certificatePublicKey(certFile); err != nil {
+					log.Println("Error reading agent certificate:", err)
+					return
+				}
+			}
+			reportData, err := attestation.ReportData(nonce, publicKey)
+			if err != nil {
+				log.Println("Error computing report data:", err)
+				return
+			}
+			certs, err := attestation.LoadCertificates(arkFile, askFile, vcekFile)
+			if err != nil {
+				log.Println("Error loading certificate chain:", err)
+				return
+			}
+			var policy attestation.Policy
+			if policyFile != "" {
+				if policy, err = attestation.LoadPolicy(policyFile); err != nil {
+					log.Println("Error loading policy:", err)
+					return
+				}
+			}
+
+			report, err := attestation.Verify(raw, certs, policy, reportData)
+			if err != nil {
+				log.Println("Attestation verification failed:", err)
+				return
+			}
+
+			log.Printf("Attestation report verified successfully! Measurement: %x, reported TCB: %#016x", report.Measurement, uint64(report.ReportedTCB))
 		},
 	}
+
+	cmd.Flags().StringVar(&nonceHex, "nonce", "", "Hex encoded nonce the report was requested with")
+	cmd.Flags().StringVar(&certFile, "cert", "", "PEM certificate of the agent, if it uses TLS")
+	cmd.Flags().StringVar(&policyFile, "policy", "", "JSON verification policy")
+	cmd.Flags().StringVar(&arkFile, "ark", attestation.ARKFile, "AMD root key certificate")
+	cmd.Flags().StringVar(&askFile, "ask", attestation.ASKFile, "AMD SEV key certificate")
+	cmd.Flags().StringVar(&vcekFile, "vcek", attestation.VCEKFile, "Versioned chip endorsement key certificate")
+	_ = cmd.MarkFlagRequired("nonce")
+
+	return cmd
+}
+
+// certificatePublicKey returns the DER encoded public key of a PEM
+// certificate.
+func certificatePublicKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.RawSubjectPublicKeyInfo, nil
 }
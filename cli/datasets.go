@@ -9,8 +9,9 @@ import (
 )
 
 func NewDatasetsCmd(sdk agentsdk.SDK) *cobra.Command {
+	var keyFile string
 
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "data",
 		Short: "Upload a dataset CSV file",
 		Args:  cobra.ExactArgs(1),
@@ -19,19 +20,39 @@ func NewDatasetsCmd(sdk agentsdk.SDK) *cobra.Command {
 
 			log.Println("Uploading dataset CSV:", datasetFile)
 
-			dataset, err := os.ReadFile(datasetFile)
-			if err != nil {
-				log.Println("Error reading dataset file:", err)
-				return
-			}
-
-			response, err := sdk.UploadDataset(dataset)
-			if err != nil {
-				log.Println("Error uploading dataset:", err)
-				return
+			var response string
+			if keyFile != "" {
+				// Encrypted artifacts are sealed as a whole, so they are
+				// read into memory and uploaded in a single message.
+				dataset, err := os.ReadFile(datasetFile)
+				if err != nil {
+					log.Println("Error reading dataset file:", err)
+					return
+				}
+				key, err := os.ReadFile(keyFile)
+				if err != nil {
+					log.Println("Error reading key file:", err)
+					return
+				}
+				response, err = sdk.UploadEncryptedDataset(dataset, key)
+				if err != nil {
+					log.Println("Error uploading dataset:", err)
+					return
+				}
+			} else {
+				var err error
+				response, err = sdk.UploadDatasetFile(datasetFile)
+				if err != nil {
+					log.Println("Error uploading dataset:", err)
+					return
+				}
 			}
 
 			log.Println("Response:", response)
 		},
 	}
+
+	cmd.Flags().StringVar(&keyFile, "key", "", "File holding a 32 byte key encrypting the dataset before upload")
+
+	return cmd
 }
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
+)
+
+func NewEventsCmd(sdk agentsdk.SDK) *cobra.Command {
+	return &cobra.Command{
+		Use:   "events",
+		Short: "Follow the progress of the computation",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := sdk.Subscribe(func(event agentsdk.Event) {
+				log.Println(describeEvent(event))
+			})
+			if err != nil {
+				log.Println("Error retrieving events:", err)
+				return
+			}
+		},
+	}
+}
+
+func describeEvent(event agentsdk.Event) string {
+	desc := fmt.Sprintf("%s %s", event.Time.Format(time.RFC3339), event.Type)
+	switch {
+	case event.ArtifactID != "":
+		desc += " " + event.ArtifactID
+	case event.Steps > 0:
+		desc += fmt.Sprintf(" algorithm %d of %d", event.Step, event.Steps)
+	case event.Error != "":
+		desc += ": " + event.Error
+	}
+
+	return desc
+}
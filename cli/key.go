@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
+)
+
+// NewKeyCmd returns the command releasing the key of an encrypted artifact.
+// Keys are only sent once the agent has been verified, i.e. when the
+// connection uses attested TLS.
+func NewKeyCmd(sdk agentsdk.SDK, attestedTLS bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "key <id> <key file>",
+		Short: "Release the key of an encrypted algorithm or dataset",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !attestedTLS {
+				log.Println("Refusing to release key: the connection to the agent does not use attested TLS")
+				return
+			}
+
+			key, err := os.ReadFile(args[1])
+			if err != nil {
+				log.Println("Error reading key file:", err)
+				return
+			}
+
+			if err := sdk.ProvideKey(args[0], key); err != nil {
+				log.Println("Error providing key:", err)
+				return
+			}
+
+			log.Println("Successfully provided key of:", args[0])
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
+)
+
+func NewLogsCmd(sdk agentsdk.SDK) *cobra.Command {
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Retrieve algorithm output",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := sdk.Logs(follow, func(entry agentsdk.LogEntry) {
+				out := os.Stdout
+				if entry.Stream == "stderr" {
+					out = os.Stderr
+				}
+				if _, err := out.Write(entry.Data); err != nil {
+					log.Println("Error writing logs:", err)
+				}
+			})
+			if err != nil {
+				log.Println("Error retrieving logs:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming output until the computation ends")
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/ultravioletrs/agent/pkg/attestation"
+	"github.com/ultravioletrs/agent/pkg/provenance"
+	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
+)
+
+const provenanceFilePath = "provenance.json"
+
+func NewProvenanceCmd(sdk agentsdk.SDK) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provenance",
+		Short: "Retrieve the signed provenance document of the result",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("Retrieving provenance document")
+
+			if err := saveProvenance(sdk); err != nil {
+				log.Println("Error retrieving provenance document:", err)
+				return
+			}
+
+			log.Println("Provenance document retrieved and saved successfully!")
+		},
+	}
+
+	cmd.AddCommand(newProvenanceVerifyCmd())
+
+	return cmd
+}
+
+func saveProvenance(sdk agentsdk.SDK) error {
+	doc, err := sdk.Provenance()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(provenanceFilePath, doc, 0644)
+}
+
+func newProvenanceVerifyCmd() *cobra.Command {
+	var (
+		policyFile string
+		arkFile    string
+		askFile    string
+		vcekFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify [document]",
+		Short: "Verify a provenance document offline",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			docFile := provenanceFilePath
+			if len(args) == 1 {
+				docFile = args[0]
+			}
+
+			data, err := os.ReadFile(docFile)
+			if err != nil {
+				log.Println("Error reading provenance document:", err)
+				return
+			}
+			certs, err := attestation.LoadCertificates(arkFile, askFile, vcekFile)
+			if err != nil {
+				log.Println("Error loading certificate chain:", err)
+				return
+			}
+			var policy attestation.Policy
+			if policyFile != "" {
+				if policy, err = attestation.LoadPolicy(policyFile); err != nil {
+					log.Println("Error loading policy:", err)
+					return
+				}
+			}
+
+			doc, err := provenance.Verify(data, certs, policy)
+			if err != nil {
+				log.Println("Provenance verification failed:", err)
+				return
+			}
+
+			log.Printf("Provenance document verified successfully! Computation %s produced result %s from algorithms %v and datasets %v", doc.ComputationID, doc.ResultDigest, doc.Algorithms, doc.Datasets)
+		},
+	}
+
+	cmd.Flags().StringVar(&policyFile, "policy", "", "JSON verification policy")
+	cmd.Flags().StringVar(&arkFile, "ark", attestation.ARKFile, "AMD root key certificate")
+	cmd.Flags().StringVar(&askFile, "ask", attestation.ASKFile, "AMD SEV key certificate")
+	cmd.Flags().StringVar(&vcekFile, "vcek", attestation.VCEKFile, "Versioned chip endorsement key certificate")
+
+	return cmd
+}
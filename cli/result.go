@@ -5,14 +5,16 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/ultravioletrs/agent/pkg/envelope"
 	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
 )
 
 const resultFilePath = "result.bin"
 
 func NewResultsCmd(sdk agentsdk.SDK) *cobra.Command {
+	var keyFile string
 
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "result",
 		Short: "Retrieve computation result file",
 		Run: func(cmd *cobra.Command, args []string) {
@@ -24,6 +26,18 @@ func NewResultsCmd(sdk agentsdk.SDK) *cobra.Command {
 				return
 			}
 
+			if keyFile != "" {
+				key, err := envelope.LoadPrivateKey(keyFile)
+				if err != nil {
+					log.Println("Error loading result key:", err)
+					return
+				}
+				if result, err = envelope.Open(result, key); err != nil {
+					log.Println("Error decrypting computation result:", err)
+					return
+				}
+			}
+
 			err = os.WriteFile(resultFilePath, result, 0644)
 			if err != nil {
 				log.Println("Error saving computation result:", err)
@@ -31,6 +45,17 @@ func NewResultsCmd(sdk agentsdk.SDK) *cobra.Command {
 			}
 
 			log.Println("Computation result retrieved and saved successfully!")
+
+			if err := saveProvenance(sdk); err != nil {
+				log.Println("Error retrieving provenance document:", err)
+				return
+			}
+
+			log.Println("Provenance document retrieved and saved successfully!")
 		},
 	}
+
+	cmd.Flags().StringVar(&keyFile, "key", "", "Private X25519 key in PEM format decrypting the sealed result")
+
+	return cmd
 }
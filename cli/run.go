@@ -29,7 +29,13 @@ func NewRunCmd(sdk agentsdk.SDK) *cobra.Command {
 				return
 			}
 
-			log.Println("Response:", response)
+			responseJSON, err := json.Marshal(response)
+			if err != nil {
+				log.Println("Error formatting computation:", err)
+				return
+			}
+
+			log.Println("Response:", string(responseJSON))
 		},
 	}
 
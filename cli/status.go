@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/spf13/cobra"
+	agentsdk "github.com/ultravioletrs/agent/pkg/sdk"
+)
+
+func NewStatusCmd(sdk agentsdk.SDK) *cobra.Command {
+
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Retrieve computation execution status",
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Println("Retrieving computation status")
+
+			status, err := sdk.Status()
+			if err != nil {
+				log.Println("Error retrieving computation status:", err)
+				return
+			}
+
+			statusJSON, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				log.Println("Error formatting computation status:", err)
+				return
+			}
+
+			log.Println("Status:", string(statusJSON))
+		},
+	}
+}
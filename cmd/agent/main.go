@@ -2,9 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	mflog "github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -19,6 +28,9 @@ import (
 	"github.com/ultravioletrs/agent/internal/server"
 	grpcserver "github.com/ultravioletrs/agent/internal/server/grpc"
 	httpserver "github.com/ultravioletrs/agent/internal/server/http"
+	"github.com/ultravioletrs/agent/pkg/attestation"
+	grpcclient "github.com/ultravioletrs/agent/pkg/clients/grpc"
+	"github.com/ultravioletrs/agent/pkg/manager"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -29,17 +41,35 @@ const (
 	svcName        = "agent"
 	envPrefixHTTP  = "AGENT_HTTP_"
 	envPrefixGRPC  = "AGENT_GRPC_"
+	envPrefixMgr   = "AGENT_MANAGER_"
 	defSvcHTTPPort = "9031"
 	defSvcGRPCPort = "7002"
+
+	attestationSNP  = "snp"
+	attestationMock = "mock"
 )
 
 type config struct {
-	LogLevel   string `env:"AGENT_LOG_LEVEL"   envDefault:"info"`
-	JaegerURL  string `env:"AGENT_JAEGER_URL"  envDefault:"http://localhost:14268/api/traces"`
-	InstanceID string `env:"AGENT_INSTANCE_ID" envDefault:""`
+	LogLevel      string        `env:"AGENT_LOG_LEVEL"              envDefault:"info"`
+	JaegerURL     string        `env:"AGENT_JAEGER_URL"             envDefault:"http://localhost:14268/api/traces"`
+	InstanceID    string        `env:"AGENT_INSTANCE_ID"            envDefault:""`
+	WorkDir       string        `env:"AGENT_WORK_DIR"               envDefault:""`
+	UploadDir     string        `env:"AGENT_UPLOAD_DIR"             envDefault:""`
+	CgroupDir     string        `env:"AGENT_CGROUP_DIR"             envDefault:""`
+	MaxResultSize int64         `env:"AGENT_MAX_RESULT_SIZE"        envDefault:"0"`
+	MaxBodySize   int64         `env:"AGENT_HTTP_MAX_BODY_SIZE"     envDefault:"0"`
+	LogBufferSize int           `env:"AGENT_LOG_BUFFER_SIZE"        envDefault:"0"`
+	Retention     time.Duration `env:"AGENT_RESULT_RETENTION"       envDefault:"0"`
+	Attestation   string        `env:"AGENT_ATTESTATION"            envDefault:"snp"`
+	MockCerts     string        `env:"AGENT_ATTESTATION_MOCK_CERTS" envDefault:""`
+	Manifest      string        `env:"AGENT_MANIFEST"               envDefault:""`
+	ManagerURL    string        `env:"AGENT_MANAGER_URL"            envDefault:""`
+	Heartbeat     time.Duration `env:"AGENT_MANAGER_HEARTBEAT"      envDefault:"0"`
 }
 
 func main() {
+	agent.SandboxInit()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -71,23 +101,106 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	svc := newService(logger, tracer)
+	var grpcServerConfig = server.Config{Port: defSvcGRPCPort}
+	if err := env.Parse(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
+		log.Fatalf("failed to load %s gRPC server configuration : %s", svcName, err.Error())
+	}
+
+	// A manifest provided at launch replaces the one sent through Run.
+	var (
+		manifest       *agent.Computation
+		manifestDigest [sha256.Size]byte
+	)
+	if cfg.Manifest != "" {
+		cmp, digest, err := agent.ReadLaunchManifest(cfg.Manifest)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("failed to read computation manifest: %s", err))
+		}
+		manifest, manifestDigest = &cmp, digest
+	}
+
+	provider, err := newAttestationProvider(cfg.Attestation, cfg.MockCerts, manifestDigest)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to create attestation provider: %s", err))
+	}
+	if cfg.Attestation == attestationMock {
+		logger.Warn("Using mock attestation provider, reports carry no hardware guarantees")
+	}
+	// The manifest is bound to the attestation reports by its digest in the
+	// host data, which the host sets when it launches the VM.
+	if manifest != nil {
+		if err := agent.CheckLaunchManifest(provider, manifestDigest); err != nil {
+			logger.Fatal(fmt.Sprintf("failed to bind computation manifest to attestation reports: %s", err))
+		}
+		if cfg.Manifest == agent.ManifestSMBIOS {
+			logger.Info("Computation manifest read from the unmeasured SMBIOS OEM strings is bound to attestation reports by host data only")
+		}
+	}
 
 	var httpServerConfig = server.Config{Port: defSvcHTTPPort}
 	if err := env.Parse(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
-		logger.Fatal(fmt.Sprintf("failed to load %s gRPC server configuration : %s", svcName, err))
+		logger.Fatal(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
 	}
-	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, cfg.InstanceID), logger)
 
-	var grpcServerConfig = server.Config{Port: defSvcGRPCPort}
-	if err := env.Parse(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
-		log.Fatalf("failed to load %s gRPC server configuration : %s", svcName, err.Error())
+	// The attested certificate is served by the servers with attested TLS
+	// and signs the provenance documents of the results.
+	var attestedCert *tls.Certificate
+	cert, err := attestation.NewAttestedCertificate(provider)
+	switch {
+	case err == nil:
+		attestedCert = &cert
+	case grpcServerConfig.AttestedTLS || httpServerConfig.AttestedTLS:
+		logger.Fatal(fmt.Sprintf("failed to create attested TLS certificate: %s", err))
+	default:
+		logger.Warn(fmt.Sprintf("Failed to create attested certificate, provenance documents will not be available: %s", err))
+	}
+	if grpcServerConfig.AttestedTLS {
+		grpcServerConfig.Certificate = attestedCert
+	}
+	if httpServerConfig.AttestedTLS {
+		httpServerConfig.Certificate = attestedCert
+	}
+
+	// Attestation reports are bound to the certificate of the server each
+	// request is received by.
+	grpcPublicKey, err := tlsPublicKey(grpcServerConfig)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to load gRPC server certificate: %s", err))
+	}
+	httpPublicKey, err := tlsPublicKey(httpServerConfig)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to load HTTP server certificate: %s", err))
+	}
+
+	core, err := agent.New(agent.Config{
+		WorkDir:               cfg.WorkDir,
+		UploadDir:             cfg.UploadDir,
+		CgroupDir:             cfg.CgroupDir,
+		MaxResultSize:         cfg.MaxResultSize,
+		LogBufferSize:         cfg.LogBufferSize,
+		ResultRetention:       cfg.Retention,
+		Attestation:           provider,
+		ProvenanceCertificate: attestedCert,
+		Manifest:              manifest,
+	})
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to create %s service: %s", svcName, err))
 	}
+	svc := newService(core, logger, tracer)
+	if manifest != nil {
+		logger.Info(fmt.Sprintf("Accepted computation %s from launch manifest, Run is disabled", manifest.ID))
+	}
+
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, cfg.InstanceID, httpServerConfig.AttestedTLS, httpPublicKey, cfg.MaxBodySize), logger)
+
 	registerAgentServiceServer := func(srv *grpc.Server) {
 		reflection.Register(srv)
-		agent.RegisterAgentServiceServer(srv, agentgrpc.NewServer(svc))
+		agent.RegisterAgentServiceServer(srv, agentgrpc.NewServer(svc, grpcServerConfig.AttestedTLS, grpcPublicKey))
 	}
-	gs := grpcserver.New(ctx, cancel, svcName, grpcServerConfig, registerAgentServiceServer, logger)
+	gs := grpcserver.New(ctx, cancel, svcName, grpcServerConfig, registerAgentServiceServer, logger,
+		grpc.ChainUnaryInterceptor(agentgrpc.UnaryErrorInterceptor),
+		grpc.ChainStreamInterceptor(agentgrpc.StreamErrorInterceptor),
+	)
 
 	g.Go(func() error {
 		return hs.Start()
@@ -97,6 +210,33 @@ func main() {
 		return gs.Start()
 	})
 
+	// The agent registers with the manager, if configured, so that the
+	// manager does not need to know the address of the agent.
+	if cfg.ManagerURL != "" {
+		var managerConfig grpcclient.Config
+		if err := env.Parse(&managerConfig, env.Options{Prefix: envPrefixMgr}); err != nil {
+			logger.Fatal(fmt.Sprintf("failed to load manager client configuration : %s", err))
+		}
+		mc, managerClient, err := grpcclient.NewManagerClient(managerConfig)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("failed to create manager client: %s", err))
+		}
+		defer mc.Close()
+		logger.Info(fmt.Sprintf("Registering with manager at %s %s", managerConfig.URL, mc.Secure()))
+
+		// The registrar uses the service without the middlewares, which
+		// would log and trace every heartbeat.
+		registrar := manager.NewClient(core, managerClient, manager.Config{
+			InstanceID: cfg.InstanceID,
+			GRPCPort:   grpcServerConfig.Port,
+			HTTPPort:   httpServerConfig.Port,
+			Heartbeat:  cfg.Heartbeat,
+		}, logger)
+		g.Go(func() error {
+			return registrar.Run(ctx)
+		})
+	}
+
 	g.Go(func() error {
 		return server.StopHandler(ctx, cancel, logger, svcName, hs, gs)
 	})
@@ -106,9 +246,9 @@ func main() {
 	}
 }
 
-func newService(logger mflog.Logger, tracer trace.Tracer) agent.Service {
-	svc := agent.New()
-
+// newService wraps the service with the logging, metrics and tracing
+// middlewares.
+func newService(svc agent.Service, logger mflog.Logger, tracer trace.Tracer) agent.Service {
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := internal.MakeMetrics(svcName, "api")
 	svc = api.MetricsMiddleware(svc, counter, latency)
@@ -116,3 +256,66 @@ func newService(logger mflog.Logger, tracer trace.Tracer) agent.Service {
 
 	return svc
 }
+
+// newAttestationProvider returns the provider of the given kind. The
+// certificate chain of the mock provider is saved into mockCerts, if set,
+// and its reports carry hostData as if the host had set it at launch.
+func newAttestationProvider(kind, mockCerts string, hostData [attestation.HostDataSize]byte) (attestation.Provider, error) {
+	switch kind {
+	case attestationSNP:
+		return attestation.NewSNPProvider(), nil
+	case attestationMock:
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if mockCerts != "" {
+			certs, err := attestation.NewMockCertificates(key)
+			if err != nil {
+				return nil, err
+			}
+			if err := certs.Save(mockCerts); err != nil {
+				return nil, err
+			}
+		}
+		// The mock measurement is the digest of the agent binary.
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		binary, err := os.ReadFile(exe)
+		if err != nil {
+			return nil, err
+		}
+		return attestation.NewMockProvider(key, sha512.Sum384(binary), hostData), nil
+	default:
+		return nil, fmt.Errorf("unknown attestation provider %q", kind)
+	}
+}
+
+// tlsPublicKey returns the DER encoded public key of the certificate served
+// with the given configuration, or nil if no certificate is configured.
+func tlsPublicKey(cfg server.Config) ([]byte, error) {
+	if cfg.Certificate != nil {
+		return cfg.Certificate.Leaf.RawSubjectPublicKeyInfo, nil
+	}
+	certFile := cfg.CertFile
+	if certFile == "" {
+		return nil, nil
+	}
+
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.RawSubjectPublicKeyInfo, nil
+}
@@ -80,9 +80,14 @@ func main() {
 	// Root Commands
 	rootCmd.AddCommand(cli.NewAlgorithmsCmd(sdk))
 	rootCmd.AddCommand(cli.NewDatasetsCmd(sdk))
+	rootCmd.AddCommand(cli.NewKeyCmd(sdk, agentGRPCConfig.AttestedTLS))
 	rootCmd.AddCommand(cli.NewResultsCmd(sdk))
 	rootCmd.AddCommand(cli.NewRunCmd(sdk))
+	rootCmd.AddCommand(cli.NewProvenanceCmd(sdk))
 	rootCmd.AddCommand(cli.NewAttestationCmd(sdk))
+	rootCmd.AddCommand(cli.NewStatusCmd(sdk))
+	rootCmd.AddCommand(cli.NewLogsCmd(sdk))
+	rootCmd.AddCommand(cli.NewEventsCmd(sdk))
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Error(fmt.Sprintf("Command execution failed: %s", err))
@@ -21,13 +21,17 @@ type Server struct {
 	server.BaseServer
 	server          *grpc.Server
 	registerService serviceRegister
+	opts            []grpc.ServerOption
 }
 
 type serviceRegister func(srv *grpc.Server)
 
 var _ server.Server = (*Server)(nil)
 
-func New(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, registerService serviceRegister, logger logger.Logger) server.Server {
+// New returns the gRPC server of the service. The options are applied after
+// the default ones, so that interceptors they chain run inside the tracing
+// interceptor.
+func New(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, registerService serviceRegister, logger logger.Logger, opts ...grpc.ServerOption) server.Server {
 	var listenFullAddress = fmt.Sprintf("%s:%s", config.Host, config.Port)
 
 	return &Server{
@@ -40,6 +44,7 @@ func New(ctx context.Context, cancel context.CancelFunc, name string, config ser
 			Logger:  logger,
 		},
 		registerService: registerService,
+		opts:            opts,
 	}
 }
 
@@ -51,23 +56,25 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to listen on port %s: %w", s.Address, err)
 	}
 
+	tlsConfig, err := server.TLSConfig(s.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load auth certificates: %w", err)
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
+	}
 	switch {
-	case s.Config.CertFile != "" || s.Config.KeyFile != "":
-		creds, err := credentials.NewServerTLSFromFile(s.Config.CertFile, s.Config.KeyFile)
-		if err != nil {
-			return fmt.Errorf("failed to load auth certificates: %w", err)
-		}
+	case tlsConfig != nil && s.Config.Certificate != nil:
+		s.Logger.Info(fmt.Sprintf("%s service gRPC server listening at %s with TLS certificate generated at startup", s.Name, s.Address))
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	case tlsConfig != nil:
 		s.Logger.Info(fmt.Sprintf("%s service gRPC server listening at %s with TLS cert %s and key %s", s.Name, s.Address, s.Config.CertFile, s.Config.KeyFile))
-		s.server = grpc.NewServer(
-			grpc.Creds(creds),
-			grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
-		)
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	default:
 		s.Logger.Info(fmt.Sprintf("%s service gRPC server listening at %s without TLS", s.Name, s.Address))
-		s.server = grpc.NewServer(
-			grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
-		)
 	}
+	s.server = grpc.NewServer(append(opts, s.opts...)...)
 
 	s.registerService(s.server)
 
@@ -42,12 +42,22 @@ func New(ctx context.Context, cancel context.CancelFunc, name string, config ser
 func (s *Server) Start() error {
 	var errCh = make(chan error)
 	s.Protocol = httpProtocol
+	tlsConfig, err := server.TLSConfig(s.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load auth certificates: %w", err)
+	}
+
 	switch {
-	case s.Config.CertFile != "" || s.Config.KeyFile != "":
+	case tlsConfig != nil:
 		s.Protocol = httpsProtocol
-		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS cert %s and key %s", s.Name, s.Protocol, s.Address, s.Config.CertFile, s.Config.KeyFile))
+		s.server.TLSConfig = tlsConfig
+		if s.Config.Certificate != nil {
+			s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS certificate generated at startup", s.Name, s.Protocol, s.Address))
+		} else {
+			s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with TLS cert %s and key %s", s.Name, s.Protocol, s.Address, s.Config.CertFile, s.Config.KeyFile))
+		}
 		go func() {
-			errCh <- s.server.ListenAndServeTLS(s.Config.CertFile, s.Config.KeyFile)
+			errCh <- s.server.ListenAndServeTLS("", "")
 		}()
 	default:
 		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s without TLS", s.Name, s.Protocol, s.Address))
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"os/signal"
@@ -16,10 +18,15 @@ type Server interface {
 }
 
 type Config struct {
-	Host     string `env:"HOST"           envDefault:""`
-	Port     string `env:"PORT"           envDefault:""`
-	CertFile string `env:"SERVER_CERT"    envDefault:""`
-	KeyFile  string `env:"SERVER_KEY"     envDefault:""`
+	Host         string `env:"HOST"            envDefault:""`
+	Port         string `env:"PORT"            envDefault:""`
+	CertFile     string `env:"SERVER_CERT"     envDefault:""`
+	KeyFile      string `env:"SERVER_KEY"      envDefault:""`
+	ClientCAFile string `env:"CLIENT_CA_CERTS" envDefault:""`
+	AttestedTLS  bool   `env:"ATTESTED_TLS"    envDefault:"false"`
+	// Certificate is served instead of CertFile and KeyFile when set. It is
+	// used for certificates generated at runtime, such as attested ones.
+	Certificate *tls.Certificate
 }
 
 type BaseServer struct {
@@ -32,6 +39,44 @@ type BaseServer struct {
 	Protocol string
 }
 
+// TLSConfig returns the TLS configuration of a server serving the certificate
+// of the given configuration, or nil if it does not use TLS. Clients may
+// authenticate with a certificate, which is verified against the client
+// authorities if they are configured.
+func TLSConfig(cfg Config) (*tls.Config, error) {
+	var cert tls.Certificate
+	switch {
+	case cfg.Certificate != nil:
+		cert = *cfg.Certificate
+	case cfg.CertFile != "" || cfg.KeyFile != "":
+		var err error
+		if cert, err = tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequestClientCert,
+	}
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
 func stopAllServers(servers ...Server) error {
 	var errs []error
 	for _, server := range servers {
@@ -0,0 +1,43 @@
+package attestation
+
+import (
+	"crypto/sha512"
+	"errors"
+)
+
+const (
+	// NonceSize is the size of the nonce a verifier sends to obtain a fresh
+	// report.
+	NonceSize = 32
+	// ReportDataSize is the size of the data embedded into a report.
+	ReportDataSize = 64
+)
+
+// ErrInvalidNonce indicates that the nonce does not have NonceSize bytes.
+var ErrInvalidNonce = errors.New("nonce must be 32 bytes long")
+
+// Provider produces attestation reports.
+type Provider interface {
+	// Report returns a signed attestation report embedding reportData.
+	Report(reportData [ReportDataSize]byte) ([]byte, error)
+}
+
+// ReportData returns the data the agent embeds into its reports. It commits
+// to the nonce of the verifier, which guarantees the freshness of the
+// report, and to the DER encoded public key of the agent's TLS certificate,
+// which binds the report to the TLS session. The public key is empty when
+// the agent does not use TLS.
+func ReportData(nonce, publicKey []byte) ([ReportDataSize]byte, error) {
+	if len(nonce) != NonceSize {
+		return [ReportDataSize]byte{}, ErrInvalidNonce
+	}
+
+	h := sha512.New()
+	h.Write(nonce)
+	h.Write(publicKey)
+
+	var data [ReportDataSize]byte
+	copy(data[:], h.Sum(nil))
+
+	return data, nil
+}
@@ -0,0 +1,4 @@
+// Package attestation contains the providers of the hardware attestation
+// reports the agent uses to prove that it runs inside a genuine confidential
+// VM.
+package attestation
@@ -0,0 +1,169 @@
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"time"
+)
+
+const (
+	// mockPolicy allows SMT and sets the reserved bit which must be one.
+	mockPolicy = 0x30000
+	// mockTCB is the TCB version reported by the mock provider: bootloader
+	// 3, TEE 0, SNP 8 and microcode 115.
+	mockTCB = 0x7308000000000003
+
+	mockRSABits  = 3072
+	mockValidity = 10 * 365 * 24 * time.Hour
+)
+
+type mockProvider struct {
+	key         *ecdsa.PrivateKey
+	measurement [MeasurementSize]byte
+	hostData    [HostDataSize]byte
+	chipID      [chipIDSize]byte
+}
+
+var _ Provider = (*mockProvider)(nil)
+
+// NewMockProvider returns a provider producing reports in the SEV-SNP
+// format signed with the given P-384 key instead of the VCEK of an AMD
+// processor. The reports carry hostData as the data provided by the host at
+// launch. It must only be used for development and tests, since it offers
+// no hardware guarantees.
+func NewMockProvider(key *ecdsa.PrivateKey, measurement [MeasurementSize]byte, hostData [HostDataSize]byte) Provider {
+	return &mockProvider{
+		key:         key,
+		measurement: measurement,
+		hostData:    hostData,
+		chipID:      mockChipID(key),
+	}
+}
+
+func (mp *mockProvider) Report(reportData [ReportDataSize]byte) ([]byte, error) {
+	report := make([]byte, ReportSize)
+	binary.LittleEndian.PutUint32(report[offVersion:], reportVersion)
+	binary.LittleEndian.PutUint64(report[offPolicy:], mockPolicy)
+	binary.LittleEndian.PutUint32(report[offSignatureAlgo:], sigAlgoECDSAP384SHA384)
+	binary.LittleEndian.PutUint64(report[offCurrentTCB:], mockTCB)
+	binary.LittleEndian.PutUint64(report[offReportedTCB:], mockTCB)
+	binary.LittleEndian.PutUint64(report[offCommittedTCB:], mockTCB)
+	binary.LittleEndian.PutUint64(report[offLaunchTCB:], mockTCB)
+	copy(report[offReportData:], reportData[:])
+	copy(report[offMeasurement:], mp.measurement[:])
+	copy(report[offHostData:], mp.hostData[:])
+	copy(report[offChipID:], mp.chipID[:])
+	if _, err := rand.Read(report[offReportID : offReportID+reportIDSize]); err != nil {
+		return nil, err
+	}
+
+	digest := sha512.Sum384(report[:signedSize])
+	r, s, err := ecdsa.Sign(rand.Reader, mp.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	// The components are stored little-endian and zero-extended.
+	putLittleEndian(report[offSignature:offSignature+signatureComponentSize], r.Bytes())
+	putLittleEndian(report[offSignature+signatureComponentSize:offSignature+2*signatureComponentSize], s.Bytes())
+
+	return report, nil
+}
+
+// NewMockCertificates returns a certificate chain verifying the reports of
+// the mock provider using key. Like the AMD chain, it consists of RSA-PSS
+// signed ARK and ASK certificates and a VCEK certificate carrying the TCB
+// version and chip ID of the mock reports.
+func NewMockCertificates(key *ecdsa.PrivateKey) (Certificates, error) {
+	arkKey, err := rsa.GenerateKey(rand.Reader, mockRSABits)
+	if err != nil {
+		return Certificates{}, err
+	}
+	askKey, err := rsa.GenerateKey(rand.Reader, mockRSABits)
+	if err != nil {
+		return Certificates{}, err
+	}
+
+	tcb := TCBVersion(mockTCB)
+	chipID := mockChipID(key)
+	var extensions []pkix.Extension
+	for _, ext := range []struct {
+		id    asn1.ObjectIdentifier
+		value interface{}
+	}{
+		{oidBootloaderSPL, int(tcb.Bootloader())},
+		{oidTEESPL, int(tcb.TEE())},
+		{oidSNPSPL, int(tcb.SNP())},
+		{oidMicrocodeSPL, int(tcb.Microcode())},
+		{oidHardwareID, chipID[:]},
+	} {
+		value, err := asn1.Marshal(ext.value)
+		if err != nil {
+			return Certificates{}, err
+		}
+		extensions = append(extensions, pkix.Extension{Id: ext.id, Value: value})
+	}
+
+	ark, err := mockCertificate(1, "ARK-Mock", &arkKey.PublicKey, nil, arkKey, true, nil)
+	if err != nil {
+		return Certificates{}, err
+	}
+	ask, err := mockCertificate(2, "SEV-Mock", &askKey.PublicKey, ark, arkKey, true, nil)
+	if err != nil {
+		return Certificates{}, err
+	}
+	vcek, err := mockCertificate(3, "SEV-VCEK", &key.PublicKey, ask, askKey, false, extensions)
+	if err != nil {
+		return Certificates{}, err
+	}
+
+	return Certificates{ARK: ark, ASK: ask, VCEK: vcek}, nil
+}
+
+func mockCertificate(serial int64, name string, pub interface{}, parent *x509.Certificate, signer *rsa.PrivateKey, ca bool, extensions []pkix.Extension) (*x509.Certificate, error) {
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name, Organization: []string{"Mock"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(mockValidity),
+		SignatureAlgorithm:    x509.SHA384WithRSAPSS,
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+		ExtraExtensions:       extensions,
+	}
+	if ca {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	if parent == nil {
+		parent = template
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+// mockChipID derives a stable chip ID from the signing key.
+func mockChipID(key *ecdsa.PrivateKey) [chipIDSize]byte {
+	return sha512.Sum512(key.PublicKey.X.Bytes())
+}
+
+// putLittleEndian writes the big-endian integer be to dst in little-endian
+// order.
+func putLittleEndian(dst, be []byte) {
+	for i, b := range be {
+		dst[len(be)-1-i] = b
+	}
+}
@@ -0,0 +1,167 @@
+package attestation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Layout of the SEV-SNP ATTESTATION_REPORT structure, as defined in table 21
+// of the SEV Secure Nested Paging Firmware ABI specification.
+const (
+	ReportSize = 0x4a0
+
+	offVersion         = 0x00
+	offGuestSVN        = 0x04
+	offPolicy          = 0x08
+	offFamilyID        = 0x10
+	offImageID         = 0x20
+	offVMPL            = 0x30
+	offSignatureAlgo   = 0x34
+	offCurrentTCB      = 0x38
+	offPlatformInfo    = 0x40
+	offReportData      = 0x50
+	offMeasurement     = 0x90
+	offHostData        = 0xc0
+	offIDKeyDigest     = 0xe0
+	offAuthorKeyDigest = 0x110
+	offReportID        = 0x140
+	offReportIDMA      = 0x160
+	offReportedTCB     = 0x180
+	offChipID          = 0x1a0
+	offCommittedTCB    = 0x1e0
+	offLaunchTCB       = 0x1f0
+	offSignature       = 0x2a0
+
+	// signedSize is the size of the part of the report covered by the
+	// signature.
+	signedSize = offSignature
+	// signatureComponentSize is the size of the little-endian R and S
+	// components of the ECDSA signature.
+	signatureComponentSize = 72
+
+	MeasurementSize = 48
+	HostDataSize    = 32
+	chipIDSize      = 64
+	reportIDSize    = 32
+
+	// reportVersion is the version of the report structure produced by
+	// current firmware.
+	reportVersion = 2
+	// sigAlgoECDSAP384SHA384 identifies ECDSA P-384 with SHA-384 signatures.
+	sigAlgoECDSAP384SHA384 = 1
+)
+
+// Guest policy bits.
+const (
+	PolicySMT            = 1 << 16
+	PolicyReserved       = 1 << 17
+	PolicyMigrationAgent = 1 << 18
+	PolicyDebug          = 1 << 19
+	PolicySingleSocket   = 1 << 20
+)
+
+var (
+	errReportSize    = errors.New("attestation report must be 1184 bytes long")
+	errReportVersion = errors.New("unsupported attestation report version")
+	errSignatureAlgo = errors.New("unsupported attestation report signature algorithm")
+)
+
+// TCBVersion is the security patch level of the firmware components of the
+// platform.
+type TCBVersion uint64
+
+// Bootloader returns the security patch level of the bootloader.
+func (v TCBVersion) Bootloader() uint8 { return uint8(v) }
+
+// TEE returns the security patch level of the PSP operating system.
+func (v TCBVersion) TEE() uint8 { return uint8(v >> 8) }
+
+// SNP returns the security patch level of the SNP firmware.
+func (v TCBVersion) SNP() uint8 { return uint8(v >> 48) }
+
+// Microcode returns the lowest current patch level of all cores.
+func (v TCBVersion) Microcode() uint8 { return uint8(v >> 56) }
+
+// Report is a parsed SEV-SNP attestation report.
+type Report struct {
+	Version         uint32
+	GuestSVN        uint32
+	Policy          uint64
+	FamilyID        [16]byte
+	ImageID         [16]byte
+	VMPL            uint32
+	SignatureAlgo   uint32
+	CurrentTCB      TCBVersion
+	PlatformInfo    uint64
+	ReportData      [ReportDataSize]byte
+	Measurement     [MeasurementSize]byte
+	HostData        [HostDataSize]byte
+	IDKeyDigest     [48]byte
+	AuthorKeyDigest [48]byte
+	ReportID        [reportIDSize]byte
+	ReportIDMA      [reportIDSize]byte
+	ReportedTCB     TCBVersion
+	ChipID          [chipIDSize]byte
+	CommittedTCB    TCBVersion
+	LaunchTCB       TCBVersion
+
+	// signed is the part of the raw report covered by the signature.
+	signed []byte
+	r, s   *big.Int
+}
+
+// ParseReport parses a raw SEV-SNP attestation report. It does not verify
+// the signature of the report.
+func ParseReport(raw []byte) (*Report, error) {
+	if len(raw) != ReportSize {
+		return nil, errReportSize
+	}
+
+	le := binary.LittleEndian
+	r := &Report{
+		Version:       le.Uint32(raw[offVersion:]),
+		GuestSVN:      le.Uint32(raw[offGuestSVN:]),
+		Policy:        le.Uint64(raw[offPolicy:]),
+		VMPL:          le.Uint32(raw[offVMPL:]),
+		SignatureAlgo: le.Uint32(raw[offSignatureAlgo:]),
+		CurrentTCB:    TCBVersion(le.Uint64(raw[offCurrentTCB:])),
+		PlatformInfo:  le.Uint64(raw[offPlatformInfo:]),
+		ReportedTCB:   TCBVersion(le.Uint64(raw[offReportedTCB:])),
+		CommittedTCB:  TCBVersion(le.Uint64(raw[offCommittedTCB:])),
+		LaunchTCB:     TCBVersion(le.Uint64(raw[offLaunchTCB:])),
+		signed:        append([]byte{}, raw[:signedSize]...),
+		r:             littleEndianInt(raw[offSignature : offSignature+signatureComponentSize]),
+		s:             littleEndianInt(raw[offSignature+signatureComponentSize : offSignature+2*signatureComponentSize]),
+	}
+	copy(r.FamilyID[:], raw[offFamilyID:])
+	copy(r.ImageID[:], raw[offImageID:])
+	copy(r.ReportData[:], raw[offReportData:])
+	copy(r.Measurement[:], raw[offMeasurement:])
+	copy(r.HostData[:], raw[offHostData:])
+	copy(r.IDKeyDigest[:], raw[offIDKeyDigest:])
+	copy(r.AuthorKeyDigest[:], raw[offAuthorKeyDigest:])
+	copy(r.ReportID[:], raw[offReportID:])
+	copy(r.ReportIDMA[:], raw[offReportIDMA:])
+	copy(r.ChipID[:], raw[offChipID:])
+
+	if r.Version < reportVersion {
+		return nil, fmt.Errorf("%w: %d", errReportVersion, r.Version)
+	}
+	if r.SignatureAlgo != sigAlgoECDSAP384SHA384 {
+		return nil, fmt.Errorf("%w: %d", errSignatureAlgo, r.SignatureAlgo)
+	}
+
+	return r, nil
+}
+
+// littleEndianInt decodes a little-endian unsigned integer.
+func littleEndianInt(le []byte) *big.Int {
+	be := make([]byte, len(le))
+	for i, b := range le {
+		be[len(le)-1-i] = b
+	}
+
+	return new(big.Int).SetBytes(be)
+}
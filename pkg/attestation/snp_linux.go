@@ -0,0 +1,91 @@
+//go:build linux
+// +build linux
+
+package attestation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// SNPGuestDevice is the device exposed by the Linux sev-guest driver.
+	SNPGuestDevice = "/dev/sev-guest"
+
+	// snpGetReport is SNP_GET_REPORT, _IOWR('S', 0x0, struct
+	// snp_guest_request_ioctl).
+	snpGetReport   = 0xc0205300
+	snpMsgVersion  = 1
+	snpRespSize    = 4000
+	snpRespHeader  = 0x20
+	snpRespOK      = 0
+	snpRequestVMPL = 0
+)
+
+// snpReportReq mirrors struct snp_report_req.
+type snpReportReq struct {
+	userData [ReportDataSize]byte
+	vmpl     uint32
+	_        [28]byte
+}
+
+// snpGuestRequest mirrors struct snp_guest_request_ioctl.
+type snpGuestRequest struct {
+	msgVersion uint8
+	_          [7]byte
+	reqData    uint64
+	respData   uint64
+	exitInfo2  uint64
+}
+
+type snpProvider struct {
+	device string
+}
+
+var _ Provider = (*snpProvider)(nil)
+
+// NewSNPProvider returns a provider requesting reports signed by the AMD
+// secure processor through the SEV-SNP guest device.
+func NewSNPProvider() Provider {
+	return &snpProvider{device: SNPGuestDevice}
+}
+
+func (sp *snpProvider) Report(reportData [ReportDataSize]byte) ([]byte, error) {
+	f, err := os.OpenFile(sp.device, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error opening SEV-SNP guest device: %w", err)
+	}
+	defer f.Close()
+
+	req := &snpReportReq{userData: reportData, vmpl: snpRequestVMPL}
+	resp := new([snpRespSize]byte)
+	guestReq := &snpGuestRequest{
+		msgVersion: snpMsgVersion,
+		reqData:    uint64(uintptr(unsafe.Pointer(req))),
+		respData:   uint64(uintptr(unsafe.Pointer(resp))),
+	}
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), snpGetReport, uintptr(unsafe.Pointer(guestReq)))
+	runtime.KeepAlive(req)
+	runtime.KeepAlive(resp)
+	if errno != 0 {
+		return nil, fmt.Errorf("error requesting SEV-SNP report: %w (firmware error %#x)", errno, guestReq.exitInfo2)
+	}
+
+	// The response starts with struct msg_report_resp holding the status
+	// and the size of the report that follows.
+	if status := binary.LittleEndian.Uint32(resp[0:]); status != snpRespOK {
+		return nil, fmt.Errorf("SEV-SNP firmware returned status %#x", status)
+	}
+	size := binary.LittleEndian.Uint32(resp[4:])
+	if size < ReportSize || size > snpRespSize-snpRespHeader {
+		return nil, fmt.Errorf("SEV-SNP firmware returned a report of %d bytes", size)
+	}
+
+	return append([]byte{}, resp[snpRespHeader:snpRespHeader+ReportSize]...), nil
+}
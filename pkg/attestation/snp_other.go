@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+package attestation
+
+import "errors"
+
+var errSNPUnsupported = errors.New("SEV-SNP attestation is only supported on Linux")
+
+type snpProvider struct{}
+
+// NewSNPProvider returns a provider which always fails, since SEV-SNP
+// guests run Linux.
+func NewSNPProvider() Provider {
+	return &snpProvider{}
+}
+
+func (sp *snpProvider) Report(reportData [ReportDataSize]byte) ([]byte, error) {
+	return nil, errSNPUnsupported
+}
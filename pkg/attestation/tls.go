@@ -0,0 +1,144 @@
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	certificateValidity = 365 * 24 * time.Hour
+	// clockSkew is subtracted from the start of the validity period of
+	// attested certificates to tolerate clients with a slow clock.
+	clockSkew = time.Hour
+)
+
+// oidReport is the object identifier of the certificate extension holding
+// the attestation report.
+var oidReport = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 58476, 1, 1}
+
+var (
+	// ErrNoReport indicates that the certificate does not embed an
+	// attestation report.
+	ErrNoReport = errors.New("certificate does not embed an attestation report")
+	// ErrInvalidCertificate indicates that the attested certificate is
+	// malformed, expired or not self-signed.
+	ErrInvalidCertificate = errors.New("invalid attested certificate")
+)
+
+// certificateNonce is the nonce committed to by the reports embedded into
+// attested certificates. Freshness is guaranteed by the TLS handshake, in
+// which the server proves possession of the attested key.
+var certificateNonce = make([]byte, NonceSize)
+
+// NewAttestedCertificate generates a P-384 key pair and returns a
+// self-signed certificate for it, which embeds an attestation report binding
+// the public key to the attested environment. The report data of the report
+// is ReportData with an all-zero nonce and the DER encoded public key.
+func NewAttestedCertificate(provider Provider) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	reportData, err := ReportData(certificateNonce, publicKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	report, err := provider.Report(reportData)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:    serial,
+		Subject:         pkix.Name{CommonName: "cocos-agent"},
+		NotBefore:       now.Add(-clockSkew),
+		NotAfter:        now.Add(certificateValidity),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{{Id: oidReport, Value: report}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+// VerifyCertificate verifies an attested certificate: it must be valid,
+// self-signed and embed a report which is verified against the certificate
+// chain and the policy and whose report data commits to the public key of
+// the certificate.
+func VerifyCertificate(cert *x509.Certificate, certs Certificates, policy Policy) (*Report, error) {
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("%w: certificate is not valid at %s", ErrInvalidCertificate, now.Format(time.RFC3339))
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCertificate, err)
+	}
+
+	var report []byte
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidReport) {
+			report = ext.Value
+			break
+		}
+	}
+	if report == nil {
+		return nil, ErrNoReport
+	}
+
+	reportData, err := ReportData(certificateNonce, cert.RawSubjectPublicKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return Verify(report, certs, policy, reportData)
+}
+
+// VerifyPeerCertificate returns a function suitable for
+// tls.Config.VerifyPeerCertificate, which verifies the attested certificate
+// presented by the server with VerifyCertificate. It is meant to be used
+// with InsecureSkipVerify set, as attested certificates are not issued by a
+// certificate authority.
+func VerifyPeerCertificate(certs Certificates, policy Policy) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrNoReport
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCertificate, err)
+		}
+		_, err = VerifyCertificate(cert, certs, policy)
+
+		return err
+	}
+}
@@ -0,0 +1,322 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// ARKFile, ASKFile and VCEKFile are the names under which the
+	// certificate chain is stored in a directory.
+	ARKFile  = "ark.pem"
+	ASKFile  = "ask.pem"
+	VCEKFile = "vcek.pem"
+
+	certPEMType = "CERTIFICATE"
+)
+
+var (
+	// ErrInvalidChain indicates that the certificate chain does not verify.
+	ErrInvalidChain = errors.New("invalid certificate chain")
+	// ErrInvalidSignature indicates that the report is not signed by the
+	// VCEK.
+	ErrInvalidSignature = errors.New("invalid attestation report signature")
+	// ErrReportDataMismatch indicates that the report does not commit to the
+	// expected nonce and public key.
+	ErrReportDataMismatch = errors.New("attestation report data mismatch")
+	// ErrPolicyViolation indicates that the report does not satisfy the
+	// verification policy.
+	ErrPolicyViolation = errors.New("attestation report violates policy")
+)
+
+// Object identifiers of the VCEK extensions holding the TCB version and the
+// chip ID the key is derived from.
+var (
+	oidBootloaderSPL = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 3704, 1, 3, 1}
+	oidTEESPL        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 3704, 1, 3, 2}
+	oidSNPSPL        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 3704, 1, 3, 3}
+	oidMicrocodeSPL  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 3704, 1, 3, 8}
+	oidHardwareID    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 3704, 1, 4}
+)
+
+// Certificates is the chain verifying attestation reports: the AMD root key
+// (ARK) signs the AMD SEV key (ASK), which signs the versioned chip
+// endorsement key (VCEK) of the processor, which signs the reports.
+type Certificates struct {
+	ARK  *x509.Certificate
+	ASK  *x509.Certificate
+	VCEK *x509.Certificate
+}
+
+// LoadCertificates reads the PEM or DER encoded certificates of the chain
+// from the given files.
+func LoadCertificates(arkPath, askPath, vcekPath string) (Certificates, error) {
+	var certs Certificates
+	for _, c := range []struct {
+		path string
+		cert **x509.Certificate
+	}{
+		{arkPath, &certs.ARK},
+		{askPath, &certs.ASK},
+		{vcekPath, &certs.VCEK},
+	} {
+		raw, err := os.ReadFile(c.path)
+		if err != nil {
+			return Certificates{}, err
+		}
+		if block, _ := pem.Decode(raw); block != nil {
+			raw = block.Bytes
+		}
+		if *c.cert, err = x509.ParseCertificate(raw); err != nil {
+			return Certificates{}, fmt.Errorf("error parsing %s: %w", c.path, err)
+		}
+	}
+
+	return certs, nil
+}
+
+// Save writes the PEM encoded certificates into dir as ARKFile, ASKFile and
+// VCEKFile.
+func (c Certificates) Save(dir string) error {
+	for name, cert := range map[string]*x509.Certificate{
+		ARKFile:  c.ARK,
+		ASKFile:  c.ASK,
+		VCEKFile: c.VCEK,
+	} {
+		data := pem.EncodeToMemory(&pem.Block{Type: certPEMType, Bytes: cert.Raw})
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verify checks that the ARK is self-signed, that every certificate is
+// signed by its parent and that all of them are valid at the given time.
+// The ARK itself is trusted as supplied: any self-signed root passes, so the
+// caller must obtain the ARK from AMD, e.g. from its Key Distribution
+// Service, and not from the party whose report is verified.
+func (c Certificates) verify(now time.Time) error {
+	if c.ARK == nil || c.ASK == nil || c.VCEK == nil {
+		return fmt.Errorf("%w: incomplete chain", ErrInvalidChain)
+	}
+
+	for _, link := range []struct {
+		name          string
+		child, parent *x509.Certificate
+	}{
+		{"ARK", c.ARK, c.ARK},
+		{"ASK", c.ASK, c.ARK},
+		{"VCEK", c.VCEK, c.ASK},
+	} {
+		// AMD certificates lack the basic constraints CheckSignatureFrom
+		// requires, so the signatures are checked directly.
+		if err := link.parent.CheckSignature(link.child.SignatureAlgorithm, link.child.RawTBSCertificate, link.child.Signature); err != nil {
+			return fmt.Errorf("%w: %s signature: %v", ErrInvalidChain, link.name, err)
+		}
+		if now.Before(link.child.NotBefore) || now.After(link.child.NotAfter) {
+			return fmt.Errorf("%w: %s is not valid at %s", ErrInvalidChain, link.name, now.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// VerifyReport parses the raw report and checks that it is signed by the
+// VCEK of a valid certificate chain, and that the TCB version and chip ID of
+// the report match the ones the VCEK was issued for.
+func VerifyReport(raw []byte, certs Certificates) (*Report, error) {
+	report, err := ParseReport(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := certs.verify(time.Now()); err != nil {
+		return nil, err
+	}
+
+	key, ok := certs.VCEK.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: VCEK is not an ECDSA key", ErrInvalidChain)
+	}
+	digest := sha512.Sum384(report.signed)
+	if !ecdsa.Verify(key, digest[:], report.r, report.s) {
+		return nil, ErrInvalidSignature
+	}
+
+	if err := checkVCEK(certs.VCEK, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// checkVCEK compares the TCB version and the hardware ID extensions of the
+// VCEK, when present, to the report.
+func checkVCEK(vcek *x509.Certificate, report *Report) error {
+	spls := map[string]uint8{
+		oidBootloaderSPL.String(): report.ReportedTCB.Bootloader(),
+		oidTEESPL.String():        report.ReportedTCB.TEE(),
+		oidSNPSPL.String():        report.ReportedTCB.SNP(),
+		oidMicrocodeSPL.String():  report.ReportedTCB.Microcode(),
+	}
+
+	for _, ext := range vcek.Extensions {
+		id := ext.Id.String()
+		if want, ok := spls[id]; ok {
+			var spl int
+			if _, err := asn1.Unmarshal(ext.Value, &spl); err != nil {
+				return fmt.Errorf("%w: invalid VCEK extension %s: %v", ErrInvalidChain, id, err)
+			}
+			if spl != int(want) {
+				return fmt.Errorf("%w: reported TCB does not match VCEK", ErrInvalidSignature)
+			}
+			continue
+		}
+		if ext.Id.Equal(oidHardwareID) {
+			hwID := ext.Value
+			// Some certificates wrap the ID in an octet string.
+			if len(hwID) != chipIDSize {
+				if _, err := asn1.Unmarshal(ext.Value, &hwID); err != nil {
+					return fmt.Errorf("%w: invalid VCEK hardware ID: %v", ErrInvalidChain, err)
+				}
+			}
+			// The chip ID is zeroed when the guest masks it.
+			if report.ChipID != [chipIDSize]byte{} && !bytes.Equal(hwID, report.ChipID[:]) {
+				return fmt.Errorf("%w: chip ID does not match VCEK", ErrInvalidSignature)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TCB is the minimum security patch level of each firmware component.
+type TCB struct {
+	Bootloader uint8 `json:"bootloader"`
+	TEE        uint8 `json:"tee"`
+	SNP        uint8 `json:"snp"`
+	Microcode  uint8 `json:"microcode"`
+}
+
+// Policy describes the attestation reports a verifier accepts.
+type Policy struct {
+	// Measurements lists the accepted hex encoded launch measurements. Any
+	// measurement is accepted when empty.
+	Measurements []string `json:"measurements,omitempty"`
+	// HostData is the expected hex encoded host data, if any.
+	HostData string `json:"host_data,omitempty"`
+	// ChipIDs lists the accepted hex encoded chip IDs. Any chip is
+	// accepted when empty.
+	ChipIDs []string `json:"chip_ids,omitempty"`
+	// MinimumTCB is the lowest accepted reported TCB version.
+	MinimumTCB TCB `json:"minimum_tcb"`
+	// MinimumGuestSVN is the lowest accepted guest security version.
+	MinimumGuestSVN uint32 `json:"minimum_guest_svn"`
+	// VMPL is the required privilege level of the VM requesting the report.
+	VMPL uint32 `json:"vmpl"`
+	// AllowSMT accepts guests which may run on hosts with simultaneous
+	// multithreading enabled.
+	AllowSMT bool `json:"allow_smt"`
+	// AllowDebug accepts guests whose memory may be debugged by the host.
+	AllowDebug bool `json:"allow_debug"`
+	// AllowMigrationAgent accepts guests which may be associated with a
+	// migration agent.
+	AllowMigrationAgent bool `json:"allow_migration_agent"`
+}
+
+// LoadPolicy reads a JSON encoded policy from path.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return Policy{}, fmt.Errorf("error parsing policy %s: %w", path, err)
+	}
+
+	return policy, nil
+}
+
+// Check returns an error describing the first requirement of the policy
+// which the report does not satisfy.
+func (p Policy) Check(report *Report) error {
+	if len(p.Measurements) > 0 && !containsHex(p.Measurements, report.Measurement[:]) {
+		return fmt.Errorf("%w: measurement %x is not accepted", ErrPolicyViolation, report.Measurement)
+	}
+	if p.HostData != "" && !containsHex([]string{p.HostData}, report.HostData[:]) {
+		return fmt.Errorf("%w: host data %x is not accepted", ErrPolicyViolation, report.HostData)
+	}
+	if len(p.ChipIDs) > 0 && !containsHex(p.ChipIDs, report.ChipID[:]) {
+		return fmt.Errorf("%w: chip ID %x is not accepted", ErrPolicyViolation, report.ChipID)
+	}
+
+	tcb := report.ReportedTCB
+	if tcb.Bootloader() < p.MinimumTCB.Bootloader || tcb.TEE() < p.MinimumTCB.TEE ||
+		tcb.SNP() < p.MinimumTCB.SNP || tcb.Microcode() < p.MinimumTCB.Microcode {
+		return fmt.Errorf("%w: reported TCB %#016x is below the minimum", ErrPolicyViolation, uint64(tcb))
+	}
+	if report.GuestSVN < p.MinimumGuestSVN {
+		return fmt.Errorf("%w: guest SVN %d is below %d", ErrPolicyViolation, report.GuestSVN, p.MinimumGuestSVN)
+	}
+	if report.VMPL != p.VMPL {
+		return fmt.Errorf("%w: VMPL %d is not %d", ErrPolicyViolation, report.VMPL, p.VMPL)
+	}
+
+	for _, bit := range []struct {
+		mask    uint64
+		allowed bool
+		name    string
+	}{
+		{PolicySMT, p.AllowSMT, "SMT"},
+		{PolicyDebug, p.AllowDebug, "debugging"},
+		{PolicyMigrationAgent, p.AllowMigrationAgent, "migration agent"},
+	} {
+		if report.Policy&bit.mask != 0 && !bit.allowed {
+			return fmt.Errorf("%w: guest policy allows %s", ErrPolicyViolation, bit.name)
+		}
+	}
+
+	return nil
+}
+
+// Verify verifies the raw report against the certificate chain, checks that
+// it embeds the expected report data and that it satisfies the policy.
+func Verify(raw []byte, certs Certificates, policy Policy, reportData [ReportDataSize]byte) (*Report, error) {
+	report, err := VerifyReport(raw, certs)
+	if err != nil {
+		return nil, err
+	}
+	if report.ReportData != reportData {
+		return nil, ErrReportDataMismatch
+	}
+	if err := policy.Check(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func containsHex(values []string, b []byte) bool {
+	for _, v := range values {
+		decoded, err := hex.DecodeString(v)
+		if err == nil && bytes.Equal(decoded, b) {
+			return true
+		}
+	}
+
+	return false
+}
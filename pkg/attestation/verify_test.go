@@ -0,0 +1,282 @@
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+var (
+	testMeasurement = [MeasurementSize]byte{1, 2, 3}
+	testHostData    = [HostDataSize]byte{7, 8, 9}
+)
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+
+	return key
+}
+
+func newTestReport(t *testing.T, key *ecdsa.PrivateKey, reportData [ReportDataSize]byte) []byte {
+	t.Helper()
+
+	raw, err := NewMockProvider(key, testMeasurement, testHostData).Report(reportData)
+	if err != nil {
+		t.Fatalf("unexpected error producing report: %s", err)
+	}
+
+	return raw
+}
+
+func TestParseReport(t *testing.T) {
+	reportData := [ReportDataSize]byte{4, 5, 6}
+	raw := newTestReport(t, newTestKey(t), reportData)
+
+	modify := func(f func(raw []byte)) []byte {
+		modified := append([]byte{}, raw...)
+		f(modified)
+		return modified
+	}
+
+	cases := []struct {
+		desc string
+		raw  []byte
+		err  error
+	}{
+		{
+			desc: "parse report",
+			raw:  raw,
+		},
+		{
+			desc: "parse truncated report",
+			raw:  raw[:ReportSize-1],
+			err:  errReportSize,
+		},
+		{
+			desc: "parse report with old version",
+			raw: modify(func(raw []byte) {
+				binary.LittleEndian.PutUint32(raw[offVersion:], reportVersion-1)
+			}),
+			err: errReportVersion,
+		},
+		{
+			desc: "parse report with unknown signature algorithm",
+			raw: modify(func(raw []byte) {
+				binary.LittleEndian.PutUint32(raw[offSignatureAlgo:], sigAlgoECDSAP384SHA384+1)
+			}),
+			err: errSignatureAlgo,
+		},
+	}
+
+	for _, tc := range cases {
+		report, err := ParseReport(tc.raw)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if report.ReportData != reportData {
+			t.Errorf("%s: expected report data %x got %x", tc.desc, reportData, report.ReportData)
+		}
+		if report.Measurement != testMeasurement {
+			t.Errorf("%s: expected measurement %x got %x", tc.desc, testMeasurement, report.Measurement)
+		}
+		if report.HostData != testHostData {
+			t.Errorf("%s: expected host data %x got %x", tc.desc, testHostData, report.HostData)
+		}
+		if report.ReportedTCB != mockTCB || report.Policy != mockPolicy {
+			t.Errorf("%s: expected TCB %#x and policy %#x got %#x and %#x", tc.desc, mockTCB, mockPolicy, report.ReportedTCB, report.Policy)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	key := newTestKey(t)
+	certs, err := NewMockCertificates(key)
+	if err != nil {
+		t.Fatalf("unexpected error creating certificates: %s", err)
+	}
+	reportData, err := ReportData(make([]byte, NonceSize), nil)
+	if err != nil {
+		t.Fatalf("unexpected error computing report data: %s", err)
+	}
+	raw := newTestReport(t, key, reportData)
+
+	tampered := append([]byte{}, raw...)
+	tampered[offMeasurement] ^= 0xff
+
+	policy := Policy{Measurements: []string{hex.EncodeToString(testMeasurement[:])}, AllowSMT: true}
+
+	cases := []struct {
+		desc       string
+		raw        []byte
+		certs      Certificates
+		policy     Policy
+		reportData [ReportDataSize]byte
+		err        error
+	}{
+		{
+			desc:       "verify report",
+			raw:        raw,
+			certs:      certs,
+			policy:     policy,
+			reportData: reportData,
+		},
+		{
+			desc:       "verify tampered report",
+			raw:        tampered,
+			certs:      certs,
+			policy:     policy,
+			reportData: reportData,
+			err:        ErrInvalidSignature,
+		},
+		{
+			desc:       "verify report signed by another key",
+			raw:        newTestReport(t, newTestKey(t), reportData),
+			certs:      certs,
+			policy:     policy,
+			reportData: reportData,
+			err:        ErrInvalidSignature,
+		},
+		{
+			desc:       "verify report with incomplete chain",
+			raw:        raw,
+			certs:      Certificates{ARK: certs.ARK, VCEK: certs.VCEK},
+			policy:     policy,
+			reportData: reportData,
+			err:        ErrInvalidChain,
+		},
+		{
+			desc:       "verify report with VCEK not signed by the ASK",
+			raw:        raw,
+			certs:      Certificates{ARK: certs.ARK, ASK: certs.ARK, VCEK: certs.VCEK},
+			policy:     policy,
+			reportData: reportData,
+			err:        ErrInvalidChain,
+		},
+		{
+			desc:       "verify report with other report data",
+			raw:        raw,
+			certs:      certs,
+			policy:     policy,
+			reportData: [ReportDataSize]byte{1},
+			err:        ErrReportDataMismatch,
+		},
+		{
+			desc:       "verify report with other measurement",
+			raw:        raw,
+			certs:      certs,
+			policy:     Policy{Measurements: []string{hex.EncodeToString(make([]byte, MeasurementSize))}, AllowSMT: true},
+			reportData: reportData,
+			err:        ErrPolicyViolation,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := Verify(tc.raw, tc.certs, tc.policy, tc.reportData)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	report, err := ParseReport(newTestReport(t, newTestKey(t), [ReportDataSize]byte{}))
+	if err != nil {
+		t.Fatalf("unexpected error parsing report: %s", err)
+	}
+	tcb := TCBVersion(mockTCB)
+
+	cases := []struct {
+		desc   string
+		policy Policy
+		err    error
+	}{
+		{
+			desc:   "check report against matching policy",
+			policy: Policy{Measurements: []string{hex.EncodeToString(testMeasurement[:])}, AllowSMT: true},
+		},
+		{
+			desc:   "check report against policy accepting another measurement",
+			policy: Policy{Measurements: []string{hex.EncodeToString(make([]byte, MeasurementSize))}, AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy expecting its host data",
+			policy: Policy{HostData: hex.EncodeToString(testHostData[:]), AllowSMT: true},
+		},
+		{
+			desc:   "check report against policy expecting other host data",
+			policy: Policy{HostData: hex.EncodeToString([]byte{1}), AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy accepting another chip",
+			policy: Policy{ChipIDs: []string{hex.EncodeToString(make([]byte, chipIDSize))}, AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy requiring newer TCB",
+			policy: Policy{MinimumTCB: TCB{Microcode: tcb.Microcode() + 1}, AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy requiring newer guest SVN",
+			policy: Policy{MinimumGuestSVN: 1, AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy requiring other VMPL",
+			policy: Policy{VMPL: 1, AllowSMT: true},
+			err:    ErrPolicyViolation,
+		},
+		{
+			desc:   "check report against policy disallowing SMT",
+			policy: Policy{},
+			err:    ErrPolicyViolation,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.policy.Check(report)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
+
+func TestReportData(t *testing.T) {
+	cases := []struct {
+		desc  string
+		nonce []byte
+		err   error
+	}{
+		{
+			desc:  "compute report data",
+			nonce: make([]byte, NonceSize),
+		},
+		{
+			desc:  "compute report data with short nonce",
+			nonce: make([]byte, NonceSize-1),
+			err:   ErrInvalidNonce,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := ReportData(tc.nonce, []byte("key"))
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package grpc
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/ultravioletrs/agent/pkg/attestation"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	gogrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -13,13 +18,21 @@ import (
 var (
 	errGrpcConnect = errors.New("failed to connect to grpc server")
 	errGrpcClose   = errors.New("failed to close grpc connection")
+	errNoCerts     = errors.New("attested TLS requires the attestation certificate chain")
+	errNoPolicy    = errors.New("attested TLS requires a policy with at least one expected measurement")
+	errCACerts     = errors.New("failed to load CA certificates")
 )
 
 type Config struct {
-	ClientTLS bool          `env:"CLIENT_TLS"    envDefault:"false"`
-	CACerts   string        `env:"CA_CERTS"      envDefault:""`
-	URL       string        `env:"URL"           envDefault:"localhost:7020"`
-	Timeout   time.Duration `env:"TIMEOUT"       envDefault:"60s"`
+	ClientTLS         bool          `env:"CLIENT_TLS"         envDefault:"false"`
+	CACerts           string        `env:"CA_CERTS"           envDefault:""`
+	ClientCert        string        `env:"CLIENT_CERT"        envDefault:""`
+	ClientKey         string        `env:"CLIENT_KEY"         envDefault:""`
+	AttestedTLS       bool          `env:"ATTESTED_TLS"       envDefault:"false"`
+	AttestationCerts  string        `env:"ATTESTATION_CERTS"  envDefault:""`
+	AttestationPolicy string        `env:"ATTESTATION_POLICY" envDefault:""`
+	URL               string        `env:"URL"                envDefault:"localhost:7020"`
+	Timeout           time.Duration `env:"TIMEOUT"            envDefault:"60s"`
 }
 
 type Client interface {
@@ -79,12 +92,29 @@ func connect(cfg Config) (*gogrpc.ClientConn, bool, error) {
 	secure := false
 	tc := insecure.NewCredentials()
 
-	if cfg.ClientTLS && cfg.CACerts != "" {
-		var err error
-		tc, err = credentials.NewClientTLSFromFile(cfg.CACerts, "")
-		if err != nil {
-			return nil, secure, err
+	var tlsConfig *tls.Config
+	var err error
+	switch {
+	case cfg.AttestedTLS:
+		tlsConfig, err = attestedTLSConfig(cfg)
+	case cfg.ClientTLS && cfg.CACerts != "":
+		tlsConfig, err = caTLSConfig(cfg.CACerts)
+	}
+	if err != nil {
+		return nil, secure, err
+	}
+
+	if tlsConfig != nil {
+		// The client certificate identifies the caller to the agent, which
+		// matches it against the parties declared in the manifest.
+		if cfg.ClientCert != "" || cfg.ClientKey != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+			if err != nil {
+				return nil, secure, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
 		}
+		tc = credentials.NewTLS(tlsConfig)
 		secure = true
 	}
 
@@ -97,3 +127,57 @@ func connect(cfg Config) (*gogrpc.ClientConn, bool, error) {
 
 	return conn, secure, nil
 }
+
+// caTLSConfig returns the configuration verifying the server certificate
+// against the authorities in the caCerts file.
+func caTLSConfig(caCerts string) (*tls.Config, error) {
+	caPEM, err := os.ReadFile(caCerts)
+	if err != nil {
+		return nil, errors.Wrap(errCACerts, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errCACerts
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// attestedTLSConfig returns the configuration accepting only servers
+// presenting an attested certificate whose report verifies against the
+// certificate chain in the AttestationCerts directory and satisfies the
+// AttestationPolicy. The policy must list the expected measurements, since
+// any genuine guest, whatever software it runs, would be accepted otherwise.
+func attestedTLSConfig(cfg Config) (*tls.Config, error) {
+	if cfg.AttestationCerts == "" {
+		return nil, errNoCerts
+	}
+	if cfg.AttestationPolicy == "" {
+		return nil, errNoPolicy
+	}
+	policy, err := attestation.LoadPolicy(cfg.AttestationPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if len(policy.Measurements) == 0 {
+		return nil, errNoPolicy
+	}
+
+	// The ARK is trusted as read, so it must be the AMD root certificate
+	// obtained from AMD rather than from the agent or its host.
+	certs, err := attestation.LoadCertificates(
+		filepath.Join(cfg.AttestationCerts, attestation.ARKFile),
+		filepath.Join(cfg.AttestationCerts, attestation.ASKFile),
+		filepath.Join(cfg.AttestationCerts, attestation.VCEKFile),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attested certificates are self-signed, so the default verification is
+	// replaced by the verification of the embedded report.
+	return &tls.Config{
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: attestation.VerifyPeerCertificate(certs, policy),
+	}, nil
+}
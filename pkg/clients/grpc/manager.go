@@ -0,0 +1,13 @@
+package grpc
+
+import "github.com/ultravioletrs/agent/pkg/manager"
+
+// NewManagerClient creates new manager gRPC client instance.
+func NewManagerClient(cfg Config) (Client, manager.ManagerServiceClient, error) {
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, manager.NewManagerServiceClient(client.Connection()), nil
+}
@@ -0,0 +1,56 @@
+package envelope
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// ArtifactKeySize is the size of the AES-256 keys encrypting artifacts.
+const ArtifactKeySize = 32
+
+// ErrInvalidArtifactKey indicates that an artifact key does not have
+// ArtifactKeySize bytes.
+var ErrInvalidArtifactKey = errors.New("artifact key must be 32 bytes long")
+
+// EncryptArtifact encrypts an algorithm or dataset with AES-256-GCM. The
+// ciphertext is the random nonce followed by the sealed data. The ID of the
+// artifact, i.e. the digest declared in the manifest, is authenticated as
+// additional data, so a ciphertext cannot be substituted for another
+// artifact encrypted with the same key.
+func EncryptArtifact(key, data []byte, id string) ([]byte, error) {
+	if len(key) != ArtifactKeySize {
+		return nil, ErrInvalidArtifactKey
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, nonceSize, nonceSize+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, data, []byte(id)), nil
+}
+
+// DecryptArtifact decrypts an artifact encrypted with EncryptArtifact.
+func DecryptArtifact(key, ciphertext []byte, id string) ([]byte, error) {
+	if len(key) != ArtifactKeySize {
+		return nil, ErrInvalidArtifactKey
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < nonceSize {
+		return nil, ErrMalformedEnvelope
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], []byte(id))
+	if err != nil {
+		return nil, ErrMalformedEnvelope
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,79 @@
+package envelope
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecryptArtifact(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, ArtifactKeySize)
+	otherKey := bytes.Repeat([]byte{2}, ArtifactKeySize)
+	data := []byte("dataset")
+	id := "id"
+
+	ciphertext, err := EncryptArtifact(key, data, id)
+	if err != nil {
+		t.Fatalf("unexpected error encrypting artifact: %s", err)
+	}
+
+	cases := []struct {
+		desc       string
+		key        []byte
+		ciphertext []byte
+		id         string
+		err        error
+	}{
+		{
+			desc:       "decrypt artifact",
+			key:        key,
+			ciphertext: ciphertext,
+			id:         id,
+		},
+		{
+			desc:       "decrypt artifact with other key",
+			key:        otherKey,
+			ciphertext: ciphertext,
+			id:         id,
+			err:        ErrMalformedEnvelope,
+		},
+		{
+			desc:       "decrypt artifact as other artifact",
+			key:        key,
+			ciphertext: ciphertext,
+			id:         "other",
+			err:        ErrMalformedEnvelope,
+		},
+		{
+			desc:       "decrypt truncated artifact",
+			key:        key,
+			ciphertext: ciphertext[:nonceSize-1],
+			id:         id,
+			err:        ErrMalformedEnvelope,
+		},
+		{
+			desc:       "decrypt artifact with short key",
+			key:        key[1:],
+			ciphertext: ciphertext,
+			id:         id,
+			err:        ErrInvalidArtifactKey,
+		},
+	}
+
+	for _, tc := range cases {
+		plaintext, err := DecryptArtifact(tc.key, tc.ciphertext, tc.id)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if err == nil && !bytes.Equal(plaintext, data) {
+			t.Errorf("%s: expected plaintext %q got %q", tc.desc, data, plaintext)
+		}
+	}
+}
+
+func TestEncryptArtifactWithShortKey(t *testing.T) {
+	if _, err := EncryptArtifact(make([]byte, ArtifactKeySize-1), []byte("dataset"), "id"); !errors.Is(err, ErrInvalidArtifactKey) {
+		t.Errorf("expected error %v got %v", ErrInvalidArtifactKey, err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package envelope seals data to the X25519 public keys of its recipients, so
+// that it can be handed over through untrusted parties, and opens sealed
+// data with the private key of a recipient. It also encrypts the algorithms
+// and datasets uploaded to the agent with symmetric keys released later.
+package envelope
@@ -0,0 +1,225 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// KeySize is the size of raw X25519 public keys.
+	KeySize = 32
+
+	dataKeySize = 32
+	nonceSize   = 12
+	// hkdfInfo separates the key encryption keys of envelopes from other
+	// keys derived from the same shared secrets.
+	hkdfInfo = "cocos envelope key"
+)
+
+var (
+	// ErrInvalidKey indicates that a key is not a valid X25519 key.
+	ErrInvalidKey = errors.New("invalid X25519 key")
+	// ErrNoRecipients indicates that data is sealed to nobody.
+	ErrNoRecipients = errors.New("envelope must have at least one recipient")
+	// ErrNotRecipient indicates that the envelope is not sealed to the key
+	// used to open it.
+	ErrNotRecipient = errors.New("key is not a recipient of the envelope")
+	// ErrMalformedEnvelope indicates that the envelope cannot be decoded or
+	// decrypted.
+	ErrMalformedEnvelope = errors.New("malformed envelope")
+)
+
+// envelope holds data encrypted with AES-256-GCM under a random data key,
+// which is wrapped for every recipient with a key encryption key derived
+// from the X25519 shared secret of the ephemeral key and the recipient key.
+type envelope struct {
+	EphemeralKey []byte      `json:"ephemeral_key"`
+	Recipients   []recipient `json:"recipients"`
+	Nonce        []byte      `json:"nonce"`
+	Ciphertext   []byte      `json:"ciphertext"`
+}
+
+type recipient struct {
+	PublicKey  []byte `json:"public_key"`
+	WrappedKey []byte `json:"wrapped_key"`
+}
+
+// Seal encrypts data so that any of the recipients can decrypt it, and
+// returns the JSON encoded envelope.
+func Seal(data []byte, recipients []*ecdh.PublicKey) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, ErrNoRecipients
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+
+	env := envelope{EphemeralKey: ephemeral.PublicKey().Bytes()}
+	for _, pub := range recipients {
+		kek, err := keyEncryptionKey(ephemeral, pub, env.EphemeralKey, pub.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := encrypt(kek, make([]byte, nonceSize), dataKey)
+		if err != nil {
+			return nil, err
+		}
+		env.Recipients = append(env.Recipients, recipient{PublicKey: pub.Bytes(), WrappedKey: wrapped})
+	}
+
+	env.Nonce = make([]byte, nonceSize)
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, err
+	}
+	if env.Ciphertext, err = encrypt(dataKey, env.Nonce, data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(env)
+}
+
+// Open decrypts the JSON encoded envelope with the private key of one of its
+// recipients.
+func Open(sealed []byte, key *ecdh.PrivateKey) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(sealed, &env); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedEnvelope, err)
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(env.EphemeralKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedEnvelope, err)
+	}
+
+	pub := key.PublicKey().Bytes()
+	for _, r := range env.Recipients {
+		if !bytes.Equal(r.PublicKey, pub) {
+			continue
+		}
+		kek, err := keyEncryptionKey(key, ephemeral, env.EphemeralKey, pub)
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := decrypt(kek, make([]byte, nonceSize), r.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		return decrypt(dataKey, env.Nonce, env.Ciphertext)
+	}
+
+	return nil, ErrNotRecipient
+}
+
+// ParsePublicKey parses a hex encoded raw X25519 public key.
+func ParsePublicKey(s string) (*ecdh.PublicKey, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil || len(raw) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return ecdh.X25519().NewPublicKey(raw)
+}
+
+// LoadPrivateKey reads a PEM encoded PKCS #8 X25519 private key, as
+// generated by `openssl genpkey -algorithm x25519`.
+func LoadPrivateKey(path string) (*ecdh.PrivateKey, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data in %s", ErrInvalidKey, path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+	xkey, ok := key.(*ecdh.PrivateKey)
+	if !ok || xkey.Curve() != ecdh.X25519() {
+		return nil, ErrInvalidKey
+	}
+
+	return xkey, nil
+}
+
+// keyEncryptionKey derives the key wrapping the data key for a recipient.
+// Both public keys are bound into the derivation, so the wrapped key is only
+// valid for this pair of keys. Since every envelope has a fresh ephemeral
+// key, key encryption keys are never reused and a fixed nonce is safe.
+func keyEncryptionKey(priv *ecdh.PrivateKey, pub *ecdh.PublicKey, ephemeralKey, recipientKey []byte) ([]byte, error) {
+	shared, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+
+	info := append([]byte(hkdfInfo), ephemeralKey...)
+	info = append(info, recipientKey...)
+
+	return hkdf(shared, info), nil
+}
+
+// hkdf derives a 32 byte key with HKDF-SHA256 (RFC 5869) without salt.
+// A single block of output is needed, so the expansion is a single HMAC.
+func hkdf(secret, info []byte) []byte {
+	extract := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+
+	expand := hmac.New(sha256.New, prk)
+	expand.Write(info)
+	expand.Write([]byte{1})
+
+	return expand.Sum(nil)
+}
+
+func encrypt(key, nonce, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nil, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, nonce, ciphertext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrMalformedEnvelope
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedEnvelope, err)
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
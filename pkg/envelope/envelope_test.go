@@ -0,0 +1,142 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestKey(t *testing.T) *ecdh.PrivateKey {
+	t.Helper()
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+
+	return key
+}
+
+func TestSealOpen(t *testing.T) {
+	alice, bob, carol := newTestKey(t), newTestKey(t), newTestKey(t)
+	data := []byte("result")
+
+	sealed, err := Seal(data, []*ecdh.PublicKey{alice.PublicKey(), bob.PublicKey()})
+	if err != nil {
+		t.Fatalf("unexpected error sealing data: %s", err)
+	}
+	if bytes.Contains(sealed, data) {
+		t.Fatalf("expected sealed data not to contain the plaintext")
+	}
+
+	tamper := func(f func(env *envelope)) []byte {
+		var env envelope
+		if err := json.Unmarshal(sealed, &env); err != nil {
+			t.Fatalf("unexpected error decoding envelope: %s", err)
+		}
+		f(&env)
+		tampered, err := json.Marshal(env)
+		if err != nil {
+			t.Fatalf("unexpected error encoding envelope: %s", err)
+		}
+		return tampered
+	}
+
+	cases := []struct {
+		desc   string
+		sealed []byte
+		key    *ecdh.PrivateKey
+		err    error
+	}{
+		{
+			desc:   "open as first recipient",
+			sealed: sealed,
+			key:    alice,
+		},
+		{
+			desc:   "open as second recipient",
+			sealed: sealed,
+			key:    bob,
+		},
+		{
+			desc:   "open as other party",
+			sealed: sealed,
+			key:    carol,
+			err:    ErrNotRecipient,
+		},
+		{
+			desc:   "open tampered ciphertext",
+			sealed: tamper(func(env *envelope) { env.Ciphertext[0] ^= 0xff }),
+			key:    alice,
+			err:    ErrMalformedEnvelope,
+		},
+		{
+			desc:   "open tampered wrapped key",
+			sealed: tamper(func(env *envelope) { env.Recipients[0].WrappedKey[0] ^= 0xff }),
+			key:    alice,
+			err:    ErrMalformedEnvelope,
+		},
+		{
+			desc: "open envelope with substituted recipient",
+			sealed: tamper(func(env *envelope) {
+				env.Recipients[0].PublicKey = carol.PublicKey().Bytes()
+			}),
+			key: carol,
+			err: ErrMalformedEnvelope,
+		},
+		{
+			desc:   "open malformed envelope",
+			sealed: []byte("result"),
+			key:    alice,
+			err:    ErrMalformedEnvelope,
+		},
+	}
+
+	for _, tc := range cases {
+		opened, err := Open(tc.sealed, tc.key)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if err == nil && !bytes.Equal(opened, data) {
+			t.Errorf("%s: expected data %q got %q", tc.desc, data, opened)
+		}
+	}
+}
+
+func TestSealWithoutRecipients(t *testing.T) {
+	if _, err := Seal([]byte("result"), nil); !errors.Is(err, ErrNoRecipients) {
+		t.Errorf("expected error %v got %v", ErrNoRecipients, err)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key := newTestKey(t).PublicKey()
+
+	cases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{desc: "parse key", key: hex.EncodeToString(key.Bytes())},
+		{desc: "parse key in upper case", key: strings.ToUpper(hex.EncodeToString(key.Bytes()))},
+		{desc: "parse short key", key: hex.EncodeToString(key.Bytes()[1:]), err: ErrInvalidKey},
+		{desc: "parse key which is not hex encoded", key: "key", err: ErrInvalidKey},
+	}
+
+	for _, tc := range cases {
+		parsed, err := ParsePublicKey(tc.key)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if err == nil && !parsed.Equal(key) {
+			t.Errorf("%s: expected key %x got %x", tc.desc, key.Bytes(), parsed.Bytes())
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/ultravioletrs/agent/agent"
+	"github.com/ultravioletrs/agent/pkg/attestation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defHeartbeat = 30 * time.Second
+	minBackoff   = time.Second
+	maxBackoff   = time.Minute
+)
+
+var errClosed = errors.New("manager closed the connection")
+
+// Config configures the registration of the agent with the manager.
+type Config struct {
+	InstanceID string
+	// GRPCPort and HTTPPort are the ports of the APIs of the agent.
+	GRPCPort string
+	HTTPPort string
+	// Heartbeat is the interval between heartbeats. Zero means 30 seconds.
+	Heartbeat time.Duration
+}
+
+// Client keeps the agent registered with the manager and forwards the
+// events of the computation to it.
+type Client struct {
+	svc     agent.Service
+	manager ManagerServiceClient
+	cfg     Config
+	logger  mflog.Logger
+}
+
+// NewClient returns the client registering svc with the manager.
+func NewClient(svc agent.Service, manager ManagerServiceClient, cfg Config, logger mflog.Logger) *Client {
+	if cfg.Heartbeat == 0 {
+		cfg.Heartbeat = defHeartbeat
+	}
+
+	return &Client{
+		svc:     svc,
+		manager: manager,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// Run connects to the manager and keeps the connection open until ctx is
+// canceled. A broken connection is reopened after a delay which doubles
+// with every failed attempt, up to a minute.
+func (c *Client) Run(ctx context.Context) error {
+	backoff := minBackoff
+	for {
+		registered, err := c.connect(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if registered {
+			backoff = minBackoff
+		}
+		c.logger.Warn(fmt.Sprintf("Connection to manager lost, reconnecting in %s: %s", backoff, err))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// connect registers the agent over a new stream and reports the events of
+// the computation and the heartbeats until the stream breaks.
+func (c *Client) connect(ctx context.Context) (registered bool, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.manager.Connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	// The manager only replies when it closes the stream, so waiting for
+	// its reply detects a broken stream between messages.
+	closed := make(chan error, 1)
+	go func() {
+		err := stream.RecvMsg(new(ConnectResponse))
+		if err == nil {
+			err = errClosed
+		}
+		closed <- err
+	}()
+	send := func(msg *AgentMessage) error {
+		err := stream.Send(msg)
+		// Send fails with io.EOF once the stream is closed, and the status
+		// of the stream is returned by the receiving side.
+		if errors.Is(err, io.EOF) {
+			return <-closed
+		}
+		return err
+	}
+
+	if err := send(&AgentMessage{Message: &AgentMessage_Registration{Registration: c.registration(ctx)}}); err != nil {
+		return false, err
+	}
+	c.logger.Info(fmt.Sprintf("Registered with manager as %s", c.cfg.InstanceID))
+
+	// Every connection replays the events from the first one, and the
+	// manager discards those it has already received by their sequence.
+	events, err := c.svc.Subscribe(ctx)
+	if err != nil {
+		return true, err
+	}
+	var sequence uint64
+
+	ticker := time.NewTicker(c.cfg.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// The computation has ended, only heartbeats follow.
+				events = nil
+				continue
+			}
+			sequence++
+			if err := send(&AgentMessage{Message: &AgentMessage_Event{Event: &Event{
+				Sequence:      sequence,
+				Type:          event.Type.String(),
+				ComputationId: event.ComputationID,
+				Time:          timestamppb.New(event.Time),
+				ArtifactId:    event.ArtifactID,
+				Step:          int32(event.Step),
+				Steps:         int32(event.Steps),
+				Error:         event.Error,
+			}}}); err != nil {
+				return true, err
+			}
+		case <-ticker.C:
+			status, err := c.svc.Status(ctx)
+			if err != nil {
+				return true, err
+			}
+			if err := send(&AgentMessage{Message: &AgentMessage_Heartbeat{Heartbeat: &Heartbeat{
+				Time:  timestamppb.Now(),
+				State: status.State.String(),
+			}}}); err != nil {
+				return true, err
+			}
+		case err := <-closed:
+			return true, err
+		}
+	}
+}
+
+// registration returns the registration of the agent, with an attestation
+// report over a fresh nonce. The agent registers without a report if it
+// fails to produce one, and the manager decides whether to accept it.
+func (c *Client) registration(ctx context.Context) *Registration {
+	reg := &Registration{
+		InstanceId: c.cfg.InstanceID,
+		GrpcPort:   c.cfg.GRPCPort,
+		HttpPort:   c.cfg.HTTPPort,
+	}
+
+	nonce := make([]byte, attestation.NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		c.logger.Warn(fmt.Sprintf("Failed to generate attestation nonce: %s", err))
+		return reg
+	}
+	report, err := c.svc.Attestation(ctx, nonce)
+	if err != nil {
+		c.logger.Warn(fmt.Sprintf("Failed to attest registration with manager: %s", err))
+		return reg
+	}
+	reg.Attestation = report
+	reg.Nonce = nonce
+
+	return reg
+}
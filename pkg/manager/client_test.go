@@ -0,0 +1,171 @@
+package manager
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mflog "github.com/mainflux/mainflux/logger"
+	"github.com/ultravioletrs/agent/agent"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testInstance  = "instance"
+	testHeartbeat = 20 * time.Millisecond
+)
+
+// connMessage is a message received by the test manager over the given
+// connection, counted from 1.
+type connMessage struct {
+	conn int
+	msg  *AgentMessage
+}
+
+// testManager records the messages of the agents, and breaks the first
+// connection after its first heartbeat.
+type testManager struct {
+	UnimplementedManagerServiceServer
+	conns int32
+	msgs  chan connMessage
+}
+
+func (m *testManager) Connect(stream ManagerService_ConnectServer) error {
+	conn := int(atomic.AddInt32(&m.conns, 1))
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		select {
+		case m.msgs <- connMessage{conn: conn, msg: msg}:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+		if conn == 1 && msg.GetHeartbeat() != nil {
+			return status.Error(codes.Unavailable, "manager restarting")
+		}
+	}
+}
+
+// newTestClient returns the manager serving over an in-process listener
+// and the client registering an agent running the computation of the
+// manifest with it.
+func newTestClient(t *testing.T) (*testManager, *Client) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	m := &testManager{msgs: make(chan connMessage)}
+	RegisterManagerServiceServer(srv, m)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error dialing manager: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	svc, err := agent.New(agent.Config{
+		WorkDir: t.TempDir(),
+		Manifest: &agent.Computation{
+			ID:                 "computation",
+			Algorithms:         []string{strings.Repeat("a", 64)},
+			Datasets:           []string{strings.Repeat("d", 64)},
+			AlgorithmProviders: []string{"provider"},
+			DatasetProviders:   []string{"provider"},
+			ResultConsumers:    []string{"consumer"},
+			Runtime:            agent.RuntimeWasm,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %s", err)
+	}
+
+	client := NewClient(svc, NewManagerServiceClient(conn), Config{
+		InstanceID: testInstance,
+		GRPCPort:   "7002",
+		HTTPPort:   "9031",
+		Heartbeat:  testHeartbeat,
+	}, mflog.NewMock())
+
+	return m, client
+}
+
+// receive returns the next message received by the manager.
+func (m *testManager) receive(t *testing.T) connMessage {
+	t.Helper()
+
+	select {
+	case cm := <-m.msgs:
+		return cm
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for agent message")
+		return connMessage{}
+	}
+}
+
+func TestClient(t *testing.T) {
+	m, client := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- client.Run(ctx)
+	}()
+
+	// Every connection registers the agent, replays the events and sends
+	// heartbeats. The second connection follows the one broken by the
+	// manager.
+	for conn := 1; conn <= 2; conn++ {
+		cm := m.receive(t)
+		reg := cm.msg.GetRegistration()
+		switch {
+		case cm.conn != conn:
+			t.Fatalf("expected message over connection %d got %d", conn, cm.conn)
+		case reg == nil:
+			t.Fatalf("connection %d: expected registration got %v", conn, cm.msg)
+		case reg.InstanceId != testInstance || reg.GrpcPort != "7002" || reg.HttpPort != "9031":
+			t.Errorf("connection %d: unexpected registration %v", conn, reg)
+		}
+
+		cm = m.receive(t)
+		event := cm.msg.GetEvent()
+		switch {
+		case event == nil:
+			t.Fatalf("connection %d: expected event got %v", conn, cm.msg)
+		case event.Sequence != 1 || event.Type != agent.EventManifestReceived.String() || event.ComputationId != "computation":
+			t.Errorf("connection %d: unexpected event %v", conn, event)
+		}
+
+		cm = m.receive(t)
+		heartbeat := cm.msg.GetHeartbeat()
+		switch {
+		case heartbeat == nil:
+			t.Fatalf("connection %d: expected heartbeat got %v", conn, cm.msg)
+		case heartbeat.State != agent.ReceivingAlgorithms.String():
+			t.Errorf("connection %d: expected state %s got %s", conn, agent.ReceivingAlgorithms, heartbeat.State)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error running client: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("client did not stop after ctx was canceled")
+	}
+}
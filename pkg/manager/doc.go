@@ -0,0 +1,4 @@
+// Package manager specifies the protocol through which agents register with
+// their manager and report their progress to it, and implements the agent
+// side of it.
+package manager
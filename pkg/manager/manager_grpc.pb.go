@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.3
+// source: pkg/manager/manager.proto
+
+package manager
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ManagerService_Connect_FullMethodName = "/manager.ManagerService/Connect"
+)
+
+// ManagerServiceClient is the client API for ManagerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ManagerServiceClient interface {
+	// Connect carries the messages of one agent. The first message registers
+	// the agent, and the following ones report its events and heartbeats. An
+	// agent reconnects whenever the stream breaks, registering again.
+	Connect(ctx context.Context, opts ...grpc.CallOption) (ManagerService_ConnectClient, error)
+}
+
+type managerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewManagerServiceClient(cc grpc.ClientConnInterface) ManagerServiceClient {
+	return &managerServiceClient{cc}
+}
+
+func (c *managerServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (ManagerService_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ManagerService_ServiceDesc.Streams[0], ManagerService_Connect_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerServiceConnectClient{stream}
+	return x, nil
+}
+
+type ManagerService_ConnectClient interface {
+	Send(*AgentMessage) error
+	CloseAndRecv() (*ConnectResponse, error)
+	grpc.ClientStream
+}
+
+type managerServiceConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerServiceConnectClient) Send(m *AgentMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *managerServiceConnectClient) CloseAndRecv() (*ConnectResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ConnectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ManagerServiceServer is the server API for ManagerService service.
+// All implementations must embed UnimplementedManagerServiceServer
+// for forward compatibility
+type ManagerServiceServer interface {
+	// Connect carries the messages of one agent. The first message registers
+	// the agent, and the following ones report its events and heartbeats. An
+	// agent reconnects whenever the stream breaks, registering again.
+	Connect(ManagerService_ConnectServer) error
+	mustEmbedUnimplementedManagerServiceServer()
+}
+
+// UnimplementedManagerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedManagerServiceServer struct {
+}
+
+func (UnimplementedManagerServiceServer) Connect(ManagerService_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedManagerServiceServer) mustEmbedUnimplementedManagerServiceServer() {}
+
+// UnsafeManagerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ManagerServiceServer will
+// result in compilation errors.
+type UnsafeManagerServiceServer interface {
+	mustEmbedUnimplementedManagerServiceServer()
+}
+
+func RegisterManagerServiceServer(s grpc.ServiceRegistrar, srv ManagerServiceServer) {
+	s.RegisterService(&ManagerService_ServiceDesc, srv)
+}
+
+func _ManagerService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagerServiceServer).Connect(&managerServiceConnectServer{stream})
+}
+
+type ManagerService_ConnectServer interface {
+	SendAndClose(*ConnectResponse) error
+	Recv() (*AgentMessage, error)
+	grpc.ServerStream
+}
+
+type managerServiceConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerServiceConnectServer) SendAndClose(m *ConnectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *managerServiceConnectServer) Recv() (*AgentMessage, error) {
+	m := new(AgentMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ManagerService_ServiceDesc is the grpc.ServiceDesc for ManagerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ManagerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "manager.ManagerService",
+	HandlerType: (*ManagerServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _ManagerService_Connect_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/manager/manager.proto",
+}
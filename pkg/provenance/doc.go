@@ -0,0 +1,3 @@
+// Package provenance describes which algorithms and datasets produced a
+// computation result in documents signed with the attested key of the agent.
+package provenance
@@ -0,0 +1,103 @@
+package provenance
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
+
+var (
+	// ErrInvalidSignature indicates that the signature of a provenance
+	// document does not verify.
+	ErrInvalidSignature = errors.New("invalid provenance signature")
+	// ErrMalformedDocument indicates that a signed provenance document
+	// cannot be decoded.
+	ErrMalformedDocument = errors.New("malformed provenance document")
+)
+
+// Document lists the inputs and the execution that produced a result.
+// Algorithms and datasets are identified by their SHA-256 digests, in the
+// order in which they are declared in the computation manifest.
+type Document struct {
+	ComputationID string    `json:"computation_id"`
+	Algorithms    []string  `json:"algorithms"`
+	Datasets      []string  `json:"datasets"`
+	Runtime       string    `json:"runtime"`
+	AgentVersion  string    `json:"agent_version"`
+	AgentCommit   string    `json:"agent_commit"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	ExitCode      int       `json:"exit_code"`
+	// ResultDigest is the SHA-256 digest of the plaintext result, also when
+	// the result is delivered sealed to the result consumers.
+	ResultDigest string `json:"result_digest"`
+}
+
+// signed is the encoding of a signed document. The signature covers the
+// exact bytes of the document, and the certificate is the attested
+// certificate of the signing key.
+type signed struct {
+	Document    json.RawMessage `json:"document"`
+	Signature   []byte          `json:"signature"`
+	Certificate []byte          `json:"certificate"`
+}
+
+// Sign signs the document with the key of the attested certificate and
+// returns the JSON encoded signed document, which embeds the certificate.
+func Sign(doc Document, cert tls.Certificate) ([]byte, error) {
+	signer, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok || len(cert.Certificate) == 0 {
+		return nil, errors.New("certificate has no signing key")
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha512.Sum384(data)
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA384)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(signed{
+		Document:    data,
+		Signature:   signature,
+		Certificate: cert.Certificate[0],
+	})
+}
+
+// Verify verifies the attested certificate embedded into a signed document
+// against the attestation certificate chain and policy, then the signature
+// of the document, and returns the document.
+func Verify(data []byte, certs attestation.Certificates, policy attestation.Policy) (Document, error) {
+	var s signed
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Document{}, fmt.Errorf("%w: %s", ErrMalformedDocument, err)
+	}
+	cert, err := x509.ParseCertificate(s.Certificate)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %s", ErrMalformedDocument, err)
+	}
+	if _, err := attestation.VerifyCertificate(cert, certs, policy); err != nil {
+		return Document{}, err
+	}
+	if err := cert.CheckSignature(x509.ECDSAWithSHA384, s.Document, s.Signature); err != nil {
+		return Document{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(s.Document, &doc); err != nil {
+		return Document{}, fmt.Errorf("%w: %s", ErrMalformedDocument, err)
+	}
+
+	return doc, nil
+}
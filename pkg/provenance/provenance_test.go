@@ -0,0 +1,180 @@
+package provenance
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ultravioletrs/agent/pkg/attestation"
+)
+
+var testMeasurement = [attestation.MeasurementSize]byte{1, 2, 3}
+
+// testAgent is an agent attested by the mock attestation provider.
+type testAgent struct {
+	cert   tls.Certificate
+	certs  attestation.Certificates
+	policy attestation.Policy
+}
+
+func newTestAgent(t *testing.T) testAgent {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	provider := attestation.NewMockProvider(key, testMeasurement, [attestation.HostDataSize]byte{})
+	cert, err := attestation.NewAttestedCertificate(provider)
+	if err != nil {
+		t.Fatalf("unexpected error creating attested certificate: %s", err)
+	}
+	certs, err := attestation.NewMockCertificates(key)
+	if err != nil {
+		t.Fatalf("unexpected error creating mock certificates: %s", err)
+	}
+
+	return testAgent{
+		cert:   cert,
+		certs:  certs,
+		policy: attestation.Policy{Measurements: []string{hex.EncodeToString(testMeasurement[:])}, AllowSMT: true},
+	}
+}
+
+func testDocument() Document {
+	return Document{
+		ComputationID: "computation",
+		Algorithms:    []string{"a1", "a2"},
+		Datasets:      []string{"d1"},
+		Runtime:       "python",
+		AgentVersion:  "0.1.0",
+		AgentCommit:   "abcdef",
+		StartTime:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		EndTime:       time.Date(2024, 1, 2, 3, 5, 5, 0, time.UTC),
+		ExitCode:      0,
+		ResultDigest:  "digest",
+	}
+}
+
+// modify decodes the signed document, applies f to it and encodes it again.
+func modify(t *testing.T, data []byte, f func(s *signed)) []byte {
+	t.Helper()
+
+	var s signed
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("unexpected error decoding signed document: %s", err)
+	}
+	f(&s)
+	modified, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("unexpected error encoding signed document: %s", err)
+	}
+
+	return modified
+}
+
+func TestSignVerify(t *testing.T) {
+	agent := newTestAgent(t)
+	other := newTestAgent(t)
+	doc := testDocument()
+
+	data, err := Sign(doc, agent.cert)
+	if err != nil {
+		t.Fatalf("unexpected error signing document: %s", err)
+	}
+	otherData, err := Sign(doc, other.cert)
+	if err != nil {
+		t.Fatalf("unexpected error signing document: %s", err)
+	}
+
+	tampered := doc
+	tampered.ResultDigest = "other digest"
+	tamperedDoc, err := json.Marshal(tampered)
+	if err != nil {
+		t.Fatalf("unexpected error encoding document: %s", err)
+	}
+
+	cases := []struct {
+		desc   string
+		data   []byte
+		certs  attestation.Certificates
+		policy attestation.Policy
+		err    error
+	}{
+		{
+			desc:   "verify signed document",
+			data:   data,
+			certs:  agent.certs,
+			policy: agent.policy,
+		},
+		{
+			desc:   "verify tampered document",
+			data:   modify(t, data, func(s *signed) { s.Document = tamperedDoc }),
+			certs:  agent.certs,
+			policy: agent.policy,
+			err:    ErrInvalidSignature,
+		},
+		{
+			desc:   "verify document signed by another attested key",
+			data:   modify(t, otherData, func(s *signed) { s.Certificate = agent.cert.Certificate[0] }),
+			certs:  agent.certs,
+			policy: agent.policy,
+			err:    ErrInvalidSignature,
+		},
+		{
+			desc:   "verify document with certificate violating the policy",
+			data:   data,
+			certs:  agent.certs,
+			policy: attestation.Policy{Measurements: []string{hex.EncodeToString(make([]byte, attestation.MeasurementSize))}, AllowSMT: true},
+			err:    attestation.ErrPolicyViolation,
+		},
+		{
+			desc:   "verify document with certificate of another processor",
+			data:   data,
+			certs:  other.certs,
+			policy: agent.policy,
+			err:    attestation.ErrInvalidSignature,
+		},
+		{
+			desc:   "verify malformed signed document",
+			data:   []byte("{"),
+			certs:  agent.certs,
+			policy: agent.policy,
+			err:    ErrMalformedDocument,
+		},
+		{
+			desc:   "verify document with malformed certificate",
+			data:   modify(t, data, func(s *signed) { s.Certificate = []byte("certificate") }),
+			certs:  agent.certs,
+			policy: agent.policy,
+			err:    ErrMalformedDocument,
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := Verify(tc.data, tc.certs, tc.policy)
+		if !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected error %v got %v", tc.desc, tc.err, err)
+			continue
+		}
+		if tc.err == nil && !reflect.DeepEqual(got, doc) {
+			t.Errorf("%s: expected document %+v got %+v", tc.desc, doc, got)
+		}
+	}
+}
+
+func TestSignWithoutKey(t *testing.T) {
+	cert := newTestAgent(t).cert
+	cert.PrivateKey = nil
+
+	if _, err := Sign(testDocument(), cert); err == nil {
+		t.Errorf("expected error signing with a certificate without key got none")
+	}
+}
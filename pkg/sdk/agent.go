@@ -2,44 +2,112 @@ package sdk
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/mainflux/mainflux/logger"
 	"github.com/ultravioletrs/agent/agent"
+	"github.com/ultravioletrs/agent/pkg/envelope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type SDK interface {
-	Run(computation Computation) (string, error)
+	Run(computation Computation) (Computation, error)
 	UploadAlgorithm(algorithm []byte) (string, error)
 	UploadDataset(dataset []byte) (string, error)
+	// UploadAlgorithmFile streams the algorithm file to the agent in chunks,
+	// without loading it into memory. If the connection drops, the upload
+	// is resumed from the number of bytes received by the agent.
+	UploadAlgorithmFile(path string) (string, error)
+	// UploadDatasetFile streams the dataset file to the agent in chunks,
+	// without loading it into memory. If the connection drops, the upload
+	// is resumed from the number of bytes received by the agent.
+	UploadDatasetFile(path string) (string, error)
+	// UploadEncryptedAlgorithm encrypts the algorithm with the key and
+	// uploads the ciphertext, so that the agent can only run it once the key
+	// is released with ProvideKey.
+	UploadEncryptedAlgorithm(algorithm, key []byte) (string, error)
+	// UploadEncryptedDataset encrypts the dataset with the key and uploads
+	// the ciphertext, so that the agent can only use it once the key is
+	// released with ProvideKey.
+	UploadEncryptedDataset(dataset, key []byte) (string, error)
+	// ProvideKey releases the key of the encrypted algorithm or dataset with
+	// the given ID.
+	ProvideKey(id string, key []byte) error
 	Result() ([]byte, error)
-	Attestation() ([]byte, error)
+	// Provenance returns the signed provenance document of the result.
+	Provenance() ([]byte, error)
+	// Attestation returns the attestation report of the agent, committing
+	// to the given nonce.
+	Attestation(nonce []byte) ([]byte, error)
+	Status() (RunStatus, error)
+	// Logs calls handle for every chunk of algorithm output. With follow
+	// set, it keeps waiting for new output until the computation ends.
+	Logs(follow bool, handle func(LogEntry)) error
+	// Subscribe calls handle for every event of the computation, starting
+	// from the first one, until the computation data is wiped.
+	Subscribe(handle func(Event)) error
 }
 
+const (
+	uploadChunkSize = 1 << 20
+	uploadAttempts  = 5
+)
+
 type agentSDK struct {
 	client agent.AgentServiceClient
 	logger logger.Logger
 }
 
-type Computation struct {
-	ID                 string    `json:"id,omitempty" db:"id"`
-	Name               string    `json:"name,omitempty" db:"name"`
-	Description        string    `json:"description,omitempty" db:"description"`
-	Status             string    `json:"status,omitempty" db:"status"`
-	Owner              string    `json:"owner,omitempty" db:"owner"`
-	StartTime          time.Time `json:"start_time,omitempty" db:"start_time"`
-	EndTime            time.Time `json:"end_time,omitempty" db:"end_time"`
-	Datasets           []string  `json:"datasets,omitempty" db:"datasets"`
-	Algorithms         []string  `json:"algorithms,omitempty" db:"algorithms"`
-	DatasetProviders   []string  `json:"dataset_providers,omitempty" db:"dataset_providers"`
-	AlgorithmProviders []string  `json:"algorithm_providers,omitempty" db:"algorithm_providers"`
-	ResultConsumers    []string  `json:"result_consumers,omitempty" db:"result_consumers"`
-	Ttl                int       `json:"ttl,omitempty" db:"ttl"`
-	Metadata           Metadata  `json:"metadata,omitempty" db:"metadata"`
+// Computation is the computation manifest sent to the agent. The SDK uses
+// the types of the agent, which are converted to their protobuf messages on
+// the wire.
+type Computation = agent.Computation
+
+type Metadata = agent.Metadata
+
+// Sandbox configures the isolation of algorithm processes. Features omitted
+// from JSON manifests are enabled by the agent, except network access which
+// is denied.
+type Sandbox = agent.Sandbox
+
+// Limits configures the resources available to the algorithms. Zero values
+// leave the corresponding resource unlimited.
+type Limits = agent.Limits
+
+// LogEntry is a chunk of output written by an algorithm to its stdout or
+// stderr stream.
+type LogEntry struct {
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+	Data   []byte    `json:"data"`
+}
+
+// Event reports a step in the progress of the computation.
+type Event struct {
+	Type          string    `json:"type"`
+	ComputationID string    `json:"computation_id"`
+	Time          time.Time `json:"time"`
+	ArtifactID    string    `json:"artifact_id,omitempty"`
+	Step          int       `json:"step,omitempty"`
+	Steps         int       `json:"steps,omitempty"`
+	Error         string    `json:"error,omitempty"`
 }
 
-type Metadata map[string]interface{}
+type RunStatus struct {
+	State     string    `json:"state"`
+	StartTime time.Time `json:"start_time,omitempty"`
+	EndTime   time.Time `json:"end_time,omitempty"`
+	ExitCode  int       `json:"exit_code"`
+	Error     string    `json:"error,omitempty"`
+	WipeTime  time.Time `json:"wipe_time,omitempty"`
+}
 
 func NewAgentSDK(log logger.Logger, agentClient agent.AgentServiceClient) *agentSDK {
 	return &agentSDK{
@@ -48,23 +116,23 @@ func NewAgentSDK(log logger.Logger, agentClient agent.AgentServiceClient) *agent
 	}
 }
 
-func (sdk *agentSDK) Run(computation Computation) (string, error) {
-	computationBytes, err := json.Marshal(computation)
+func (sdk *agentSDK) Run(computation Computation) (Computation, error) {
+	manifest, err := agent.ComputationToManifest(computation)
 	if err != nil {
-		sdk.logger.Error("Failed to marshal computation")
-		return "", err
+		sdk.logger.Error("Failed to convert computation")
+		return Computation{}, err
 	}
 
 	request := &agent.RunRequest{
-		Computation: computationBytes,
+		Computation: manifest,
 	}
 	response, err := sdk.client.Run(context.Background(), request)
 	if err != nil {
 		sdk.logger.Error("Failed to call Run RPC")
-		return "", err
+		return Computation{}, err
 	}
 
-	return response.Computation, nil
+	return agent.ManifestToComputation(response.Computation), nil
 }
 
 func (sdk *agentSDK) UploadAlgorithm(algorithm []byte) (string, error) {
@@ -95,6 +163,178 @@ func (sdk *agentSDK) UploadDataset(dataset []byte) (string, error) {
 	return response.DatasetID, nil
 }
 
+func (sdk *agentSDK) UploadAlgorithmFile(path string) (string, error) {
+	return sdk.uploadFile(path, func(ctx context.Context) (uploadStream, error) {
+		return sdk.client.UploadAlgorithm(ctx)
+	})
+}
+
+func (sdk *agentSDK) UploadDatasetFile(path string) (string, error) {
+	return sdk.uploadFile(path, func(ctx context.Context) (uploadStream, error) {
+		return sdk.client.UploadDataset(ctx)
+	})
+}
+
+// uploadStream is the client side of the UploadAlgorithm and UploadDataset
+// streams.
+type uploadStream interface {
+	Send(*agent.UploadRequest) error
+	CloseAndRecv() (*agent.UploadResponse, error)
+}
+
+// uploadFile uploads the file through the streams returned by open,
+// resuming the upload after failures caused by an unavailable connection.
+// After such a failure, the agent reports the upload to be in progress as a
+// failed precondition until it notices the end of the interrupted stream,
+// so the upload is resumed after that error as well.
+func (sdk *agentSDK) uploadFile(path string, open func(context.Context) (uploadStream, error)) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	id, size, err := fileDigest(f)
+	if err != nil {
+		sdk.logger.Error("Failed to compute file digest")
+		return "", err
+	}
+
+	interrupted := false
+	for attempt := 1; ; attempt++ {
+		err = sdk.resumeUpload(f, id, size, open)
+		if err == nil {
+			return id, nil
+		}
+		code := status.Code(err)
+		retry := code == codes.Unavailable || (interrupted && code == codes.FailedPrecondition)
+		if attempt == uploadAttempts || !retry {
+			sdk.logger.Error("Failed to upload file")
+			return "", err
+		}
+		interrupted = true
+		sdk.logger.Warn(fmt.Sprintf("Upload of %s interrupted, resuming: %s", id, err))
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+}
+
+// resumeUpload sends the part of the file the agent has not received yet.
+func (sdk *agentSDK) resumeUpload(f *os.File, id string, size int64, open func(context.Context) (uploadStream, error)) error {
+	ctx := context.Background()
+	progress, err := sdk.client.UploadProgress(ctx, &agent.UploadProgressRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	if progress.Complete {
+		return nil
+	}
+	if _, err := f.Seek(progress.Received, io.SeekStart); err != nil {
+		return err
+	}
+
+	stream, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	req := &agent.UploadRequest{
+		Id:     id,
+		Size:   size,
+		Offset: progress.Received,
+	}
+	for {
+		// Sent messages may be used after Send returns, so every chunk
+		// gets its own buffer.
+		chunk := make([]byte, uploadChunkSize)
+		n, err := f.Read(chunk)
+		if n > 0 {
+			req.Chunk = chunk[:n]
+			if err := stream.Send(req); err != nil {
+				// The server closed the stream, whose status is
+				// returned by CloseAndRecv.
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			req = &agent.UploadRequest{}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if !res.Complete {
+		return fmt.Errorf("upload of %s incomplete: %d of %d bytes received", id, res.Received, res.Size)
+	}
+
+	return nil
+}
+
+func (sdk *agentSDK) UploadEncryptedAlgorithm(algorithm, key []byte) (string, error) {
+	id := digest(algorithm)
+	ciphertext, err := envelope.EncryptArtifact(key, algorithm, id)
+	if err != nil {
+		sdk.logger.Error("Failed to encrypt algorithm")
+		return "", err
+	}
+
+	request := &agent.AlgoRequest{
+		Algorithm: ciphertext,
+		Id:        id,
+	}
+
+	response, err := sdk.client.Algo(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Algo RPC")
+		return "", err
+	}
+
+	return response.AlgorithmID, nil
+}
+
+func (sdk *agentSDK) UploadEncryptedDataset(dataset, key []byte) (string, error) {
+	id := digest(dataset)
+	ciphertext, err := envelope.EncryptArtifact(key, dataset, id)
+	if err != nil {
+		sdk.logger.Error("Failed to encrypt dataset")
+		return "", err
+	}
+
+	request := &agent.DataRequest{
+		Dataset: ciphertext,
+		Id:      id,
+	}
+
+	response, err := sdk.client.Data(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Data RPC")
+		return "", err
+	}
+
+	return response.DatasetID, nil
+}
+
+func (sdk *agentSDK) ProvideKey(id string, key []byte) error {
+	request := &agent.ProvideKeyRequest{
+		Id:  id,
+		Key: key,
+	}
+
+	if _, err := sdk.client.ProvideKey(context.Background(), request); err != nil {
+		sdk.logger.Error("Failed to call ProvideKey RPC")
+		return err
+	}
+
+	return nil
+}
+
 func (sdk *agentSDK) Result() ([]byte, error) {
 	request := &agent.ResultRequest{}
 
@@ -107,8 +347,20 @@ func (sdk *agentSDK) Result() ([]byte, error) {
 	return response.File, nil
 }
 
-func (sdk *agentSDK) Attestation() ([]byte, error) {
-	request := &agent.AttestationRequest{}
+func (sdk *agentSDK) Provenance() ([]byte, error) {
+	request := &agent.ProvenanceRequest{}
+
+	response, err := sdk.client.Provenance(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Provenance RPC")
+		return nil, err
+	}
+
+	return response.File, nil
+}
+
+func (sdk *agentSDK) Attestation(nonce []byte) ([]byte, error) {
+	request := &agent.AttestationRequest{Nonce: nonce}
 
 	response, err := sdk.client.Attestation(context.Background(), request)
 	if err != nil {
@@ -118,3 +370,105 @@ func (sdk *agentSDK) Attestation() ([]byte, error) {
 
 	return response.File, nil
 }
+
+func (sdk *agentSDK) Status() (RunStatus, error) {
+	request := &agent.StatusRequest{}
+
+	response, err := sdk.client.Status(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Status RPC")
+		return RunStatus{}, err
+	}
+
+	status := RunStatus{
+		State:    response.State,
+		ExitCode: int(response.ExitCode),
+		Error:    response.Error,
+	}
+	if response.StartTime != nil {
+		status.StartTime = response.StartTime.AsTime()
+	}
+	if response.EndTime != nil {
+		status.EndTime = response.EndTime.AsTime()
+	}
+	if response.WipeTime != nil {
+		status.WipeTime = response.WipeTime.AsTime()
+	}
+
+	return status, nil
+}
+
+func (sdk *agentSDK) Logs(follow bool, handle func(LogEntry)) error {
+	request := &agent.LogsRequest{Follow: follow}
+
+	stream, err := sdk.client.Logs(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Logs RPC")
+		return err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			sdk.logger.Error("Failed to receive logs")
+			return err
+		}
+		handle(LogEntry{
+			Stream: response.Stream,
+			Time:   response.Time.AsTime(),
+			Data:   response.Data,
+		})
+	}
+}
+
+func (sdk *agentSDK) Subscribe(handle func(Event)) error {
+	request := &agent.SubscribeRequest{}
+
+	stream, err := sdk.client.Subscribe(context.Background(), request)
+	if err != nil {
+		sdk.logger.Error("Failed to call Subscribe RPC")
+		return err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			sdk.logger.Error("Failed to receive events")
+			return err
+		}
+		handle(Event{
+			Type:          response.Type.String(),
+			ComputationID: response.ComputationId,
+			Time:          response.Time.AsTime(),
+			ArtifactID:    response.ArtifactId,
+			Step:          int(response.Step),
+			Steps:         int(response.Steps),
+			Error:         response.Error,
+		})
+	}
+}
+
+// fileDigest returns the hex encoded SHA-256 digest and the size of the
+// file.
+func fileDigest(f *os.File) (string, int64, error) {
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// digest returns the hex encoded SHA-256 digest identifying an algorithm or
+// dataset in the computation manifest.
+func digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,12 +1,15 @@
 package socket
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"os"
 )
 
+var ErrDataTooLarge = errors.New("received data exceeds size limit")
+
 func StartUnixSocketServer(socketPath string) (net.Listener, error) {
 	// Remove any existing socket file
 	_ = os.Remove(socketPath)
@@ -22,16 +25,19 @@ func StartUnixSocketServer(socketPath string) (net.Listener, error) {
 	return listener, nil
 }
 
-func AcceptConnection(listener net.Listener, dataChannel chan []byte, errorChannel chan error) {
+// AcceptConnection accepts a single connection and reads from it until EOF.
+// At most maxSize bytes are read; a non-positive maxSize disables the limit.
+func AcceptConnection(listener net.Listener, maxSize int64, dataChannel chan []byte, errorChannel chan error) {
 	conn, err := listener.Accept()
 	if err != nil {
 		errorChannel <- fmt.Errorf("error accepting connection:: %v", err)
+		return
 	}
 
-	handleConnection(conn, dataChannel, errorChannel)
+	handleConnection(conn, maxSize, dataChannel, errorChannel)
 }
 
-func handleConnection(conn net.Conn, dataChannel chan []byte, errorChannel chan error) {
+func handleConnection(conn net.Conn, maxSize int64, dataChannel chan []byte, errorChannel chan error) {
 	defer conn.Close()
 
 	// Create a dynamic buffer to store incoming data
@@ -46,8 +52,13 @@ func handleConnection(conn net.Conn, dataChannel chan []byte, errorChannel chan
 				break
 			}
 			errorChannel <- err
+			return
 		}
 		buffer = append(buffer, tmp[:n]...)
+		if maxSize > 0 && int64(len(buffer)) > maxSize {
+			errorChannel <- ErrDataTooLarge
+			return
+		}
 	}
 
 	dataChannel <- buffer
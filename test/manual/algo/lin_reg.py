@@ -7,7 +7,7 @@ from sklearn.model_selection import train_test_split
 from sklearn.linear_model import LogisticRegression
 
 dataset = sys.argv[1]
-iris = pd.read_csv(io.StringIO(dataset))
+iris = pd.read_csv(dataset)
 
 # Droping the Species since we only need the measurements
 X = iris.drop(['Species'], axis=1)